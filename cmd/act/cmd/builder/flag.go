@@ -35,7 +35,7 @@ var FlagRegistry = []Flag{
 		Value:         aws.String(constants.EmptyString),
 		DefValue:      "ap-northeast-2",
 		FlagAddMethod: "StringVar",
-		DefinedOn:     []string{"rds-token", "who", "describe-web-acl"},
+		DefinedOn:     []string{"rds-token", "who", "describe-web-acl", "has-ip", "add-ip", "remove-ip", "sync", "export", "exec", "ecr-login", "setup", "console", "start", "stop", "status"},
 	},
 	{
 		Name:          "duration",
@@ -44,7 +44,7 @@ var FlagRegistry = []Flag{
 		Value:         aws.Int(constants.DefaultDuration),
 		DefValue:      constants.DefaultDuration,
 		FlagAddMethod: "IntVar",
-		DefinedOn:     []string{"setup"},
+		DefinedOn:     []string{"setup", "exec", "credential-process", "serve", "ecr-login", "console", "start", "stop", "status", "on", "off", "schedule"},
 	},
 	{
 		Name:          "profile",
@@ -53,7 +53,7 @@ var FlagRegistry = []Flag{
 		Value:         aws.String("default"),
 		DefValue:      "default",
 		FlagAddMethod: "StringVar",
-		DefinedOn:     []string{"rds-token", "setup", "list", "renew-credential", "describe-web-acl", "has-ip", "start", "stop", "status", "exec", "ecr-login"},
+		DefinedOn:     []string{"rds-token", "setup", "list", "renew-credential", "describe-web-acl", "has-ip", "start", "stop", "status", "exec", "ecr-login", "remove", "clear", "credential-process", "serve", "logout", "console", "on", "off", "schedule", "add-ip", "remove-ip", "sync", "export"},
 	},
 	{
 		Name:          "raw-output",
@@ -61,8 +61,170 @@ var FlagRegistry = []Flag{
 		Value:         aws.Bool(false),
 		DefValue:      false,
 		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"setup", "console"},
+	},
+	{
+		Name:          "format",
+		Usage:         "Output format of credentials: sh, fish, powershell, dotenv, json or github-actions. Detected from shell if empty",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
 		DefinedOn:     []string{"setup"},
 	},
+	{
+		Name:          "service",
+		Usage:         "Service of AWS console to open, e.g. ec2, s3, cloudwatch",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"console"},
+	},
+	{
+		Name:          "open",
+		Usage:         "Open sign-in URL with the default browser",
+		Value:         aws.Bool(false),
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"console"},
+	},
+	{
+		Name:          "wait",
+		Usage:         "Wait until all loadtest resources are started or stopped",
+		Value:         aws.Bool(false),
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"start", "stop"},
+	},
+	{
+		Name:          "yes",
+		Shorthand:     "y",
+		Usage:         "Apply changes without confirmation",
+		Value:         aws.Bool(false),
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"on", "off", "schedule", "add-ip", "remove-ip", "sync"},
+	},
+	{
+		Name:          "end",
+		Usage:         "End time of maintenance in RFC3339 or '2006-01-02 15:04' format, which can be used in response template",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"on", "schedule"},
+	},
+	{
+		Name:          "start",
+		Usage:         "Start time of maintenance in RFC3339 or '2006-01-02 15:04' format",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"schedule"},
+	},
+	{
+		Name:          "waf-version",
+		Usage:         "Version of WAF API: auto, classic or v2",
+		Value:         aws.String(constants.AutoWafVersion),
+		DefValue:      constants.AutoWafVersion,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"describe-web-acl", "has-ip", "add-ip", "remove-ip", "sync", "export"},
+	},
+	{
+		Name:          "scope",
+		Usage:         "Scope of WAF resources: REGIONAL or CLOUDFRONT. All scopes are searched if empty",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"describe-web-acl", "has-ip", "add-ip", "remove-ip", "sync", "export"},
+	},
+	{
+		Name:          "ip-set",
+		Usage:         "ID or name of WAF IP set. You can choose it from the list if empty",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"add-ip", "remove-ip", "export"},
+	},
+	{
+		Name:          "web-acl",
+		Usage:         "ID or name of web ACL. You can choose it from the list if empty",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"has-ip"},
+	},
+	{
+		Name:          "file",
+		Shorthand:     "f",
+		Usage:         "File of IP sets in YAML or CSV, or file of IP addresses for has-ip. '-' reads IP addresses from stdin, which needs --all or --web-acl for has-ip",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"sync", "export", "has-ip"},
+	},
+	{
+		Name:          "all",
+		Usage:         "Search IP addresses in all web ACLs",
+		Value:         aws.Bool(false),
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"has-ip"},
+	},
+	{
+		Name:          "concurrency",
+		Usage:         "Number of WAF API requests sent at the same time",
+		Value:         aws.Int(constants.DefaultWafConcurrency),
+		DefValue:      constants.DefaultWafConcurrency,
+		FlagAddMethod: "IntVar",
+		DefinedOn:     []string{"describe-web-acl", "has-ip"},
+	},
+	{
+		Name:          "file-format",
+		Usage:         "Format of IP set file: yaml or csv. Detected from file extension if empty",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"sync", "export"},
+	},
+	{
+		Name:          "prune",
+		Usage:         "Remove addresses which are not in the file. Only addresses in the file are added if false",
+		Value:         aws.Bool(true),
+		DefValue:      true,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"sync"},
+	},
+	{
+		Name:          "allow-empty",
+		Usage:         "Allow IP set to be pruned to empty when it has no address in the file",
+		Value:         aws.Bool(false),
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"sync"},
+	},
+	{
+		Name:          "address",
+		Usage:         "Address of local credential server",
+		Value:         aws.String(constants.DefaultServeAddress),
+		DefValue:      constants.DefaultServeAddress,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"serve"},
+	},
+	{
+		Name:          "port",
+		Usage:         "Port of local credential server",
+		Value:         aws.Int(constants.DefaultServePort),
+		DefValue:      constants.DefaultServePort,
+		FlagAddMethod: "IntVar",
+		DefinedOn:     []string{"serve"},
+	},
+	{
+		Name:          "token",
+		Usage:         "Bearer token of local credential server, random token is generated if empty",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"serve"},
+	},
 }
 
 func (fl *Flag) flag() *pflag.Flag {
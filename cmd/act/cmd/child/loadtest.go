@@ -0,0 +1,69 @@
+package child
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/executor"
+)
+
+// Start loadtest environment
+func NewCmdLoadtestStart() *cobra.Command {
+	return builder.NewCmd("start").
+		WithDescription("Start RDS clusters and autoscaling groups of loadtest environment").
+		SetFlags().
+		RunWithArgsAndCmd(funcLoadtestStart)
+}
+
+// Function for loadtest start command
+func funcLoadtestStart(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutor(ctx, constants.NeedExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.StartLoadtest(ctx, out, args)
+	})
+}
+
+// Stop loadtest environment
+func NewCmdLoadtestStop() *cobra.Command {
+	return builder.NewCmd("stop").
+		WithDescription("Stop RDS clusters and autoscaling groups of loadtest environment").
+		SetFlags().
+		RunWithArgsAndCmd(funcLoadtestStop)
+}
+
+// Function for loadtest stop command
+func funcLoadtestStop(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutor(ctx, constants.NeedExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.StopLoadtest(ctx, out, args)
+	})
+}
+
+// Show status of loadtest environment
+func NewCmdLoadtestStatus() *cobra.Command {
+	return builder.NewCmd("status").
+		WithDescription("Show status of RDS clusters and autoscaling groups of loadtest environment").
+		SetFlags().
+		RunWithArgsAndCmd(funcLoadtestStatus)
+}
+
+// Function for loadtest status command
+func funcLoadtestStatus(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutor(ctx, constants.NeedExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.PrintLoadtestStatus(out, args)
+	})
+}
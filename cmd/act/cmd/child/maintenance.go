@@ -0,0 +1,91 @@
+package child
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/executor"
+)
+
+// Turn on maintenance mode
+func NewCmdMaintenanceOn() *cobra.Command {
+	return builder.NewCmd("on").
+		WithDescription("Create maintenance rule in all listeners of load balancers").
+		SetFlags().
+		RunWithArgsAndCmd(funcMaintenanceOn)
+}
+
+// Function for maintenance on command
+func funcMaintenanceOn(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutor(ctx, constants.NeedExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.MaintenanceOn(out, args)
+	})
+}
+
+// Turn off maintenance mode
+func NewCmdMaintenanceOff() *cobra.Command {
+	return builder.NewCmd("off").
+		WithDescription("Remove maintenance rules created by act").
+		SetFlags().
+		RunWithArgsAndCmd(funcMaintenanceOff)
+}
+
+// Function for maintenance off command
+func funcMaintenanceOff(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutor(ctx, constants.NeedExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.MaintenanceOff(out, args)
+	})
+}
+
+// Show status of maintenance mode
+func NewCmdMaintenanceStatus() *cobra.Command {
+	return builder.NewCmd("status").
+		WithDescription("Show listeners which are in maintenance").
+		SetFlags().
+		RunWithArgsAndCmd(funcMaintenanceStatus)
+}
+
+// Function for maintenance status command
+func funcMaintenanceStatus(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutor(ctx, constants.NeedExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.PrintMaintenanceStatus(out, args)
+	})
+}
+
+// Schedule maintenance window
+func NewCmdMaintenanceSchedule() *cobra.Command {
+	return builder.NewCmd("schedule").
+		WithDescription("Turn on maintenance at start time and turn off at end time in foreground").
+		WithLongDescription("Turn on maintenance at start time and turn off at end time in foreground\n\n" +
+			"usage: act maintenance schedule [env] --start [time] --end [time]\n" +
+			"saved schedule is resumed if --start and --end are omitted").
+		SetFlags().
+		RunWithArgsAndCmd(funcMaintenanceSchedule)
+}
+
+// Function for maintenance schedule command
+func funcMaintenanceSchedule(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutor(ctx, constants.NeedExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.ScheduleMaintenance(ctx, out, args)
+	})
+}
@@ -0,0 +1,41 @@
+package child
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/pkg/executor"
+)
+
+// Show status of MFA session
+func NewCmdMFAStatus() *cobra.Command {
+	return builder.NewCmd("status").
+		WithDescription("Show remaining lifetime of MFA session").
+		SetFlags().
+		RunWithNoArgs(funcMFAStatus)
+}
+
+// Function for mfa status command
+func funcMFAStatus(ctx context.Context, out io.Writer) error {
+	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
+		return executor.Runner.PrintMFAStatus(out)
+	})
+}
+
+// Remove MFA session
+func NewCmdMFALogout() *cobra.Command {
+	return builder.NewCmd("logout").
+		WithDescription("Remove MFA session and role credentials assumed from it").
+		SetFlags().
+		RunWithNoArgs(funcMFALogout)
+}
+
+// Function for mfa logout command
+func funcMFALogout(ctx context.Context, out io.Writer) error {
+	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
+		return executor.Runner.MFALogout(out)
+	})
+}
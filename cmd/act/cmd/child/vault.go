@@ -0,0 +1,63 @@
+package child
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/pkg/executor"
+)
+
+// List credentials in the vault
+func NewCmdVaultList() *cobra.Command {
+	return builder.NewCmd("list").
+		WithDescription("List all credentials cached in the vault").
+		SetAliases([]string{"ls"}).
+		SetFlags().
+		RunWithNoArgs(funcVaultList)
+}
+
+// Function for vault list command
+func funcVaultList(ctx context.Context, out io.Writer) error {
+	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
+		return executor.Runner.ListVault(out)
+	})
+}
+
+// Remove credentials from the vault
+func NewCmdVaultRemove() *cobra.Command {
+	return builder.NewCmd("remove").
+		WithDescription("Remove credentials from the vault").
+		SetAliases([]string{"rm"}).
+		SetFlags().
+		RunWithArgs(funcVaultRemove)
+}
+
+// Function for vault remove command
+func funcVaultRemove(ctx context.Context, out io.Writer, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: act vault remove [KEY1] [KEY2]... ")
+	}
+
+	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
+		return executor.Runner.RemoveVaultItem(out, args)
+	})
+}
+
+// Clear all credentials in the vault
+func NewCmdVaultClear() *cobra.Command {
+	return builder.NewCmd("clear").
+		WithDescription("Remove all credentials in the vault").
+		SetFlags().
+		RunWithNoArgs(funcVaultClear)
+}
+
+// Function for vault clear command
+func funcVaultClear(ctx context.Context, out io.Writer) error {
+	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
+		return executor.Runner.ClearVault(out)
+	})
+}
@@ -0,0 +1,87 @@
+package child
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/pkg/executor"
+)
+
+// Add addresses to WAF IP set
+func NewCmdWafAddIP() *cobra.Command {
+	return builder.NewCmd("add-ip").
+		WithDescription("Add IP addresses or CIDRs to WAF IP set").
+		SetFlags().
+		RunWithArgsAndCmd(funcWafAddIP)
+}
+
+// Function for waf add-ip command
+func funcWafAddIP(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
+		return executor.Runner.AddIPToIPSet(out, args)
+	})
+}
+
+// Remove addresses from WAF IP set
+func NewCmdWafRemoveIP() *cobra.Command {
+	return builder.NewCmd("remove-ip").
+		WithDescription("Remove IP addresses or CIDRs from WAF IP set").
+		SetFlags().
+		RunWithArgsAndCmd(funcWafRemoveIP)
+}
+
+// Function for waf remove-ip command
+func funcWafRemoveIP(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
+		return executor.Runner.RemoveIPFromIPSet(out, args)
+	})
+}
+
+// Synchronize WAF IP sets with file
+func NewCmdWafSync() *cobra.Command {
+	return builder.NewCmd("sync").
+		WithDescription("Synchronize addresses of WAF IP sets with YAML or CSV file").
+		SetFlags().
+		RunWithArgsAndCmd(funcWafSync)
+}
+
+// Function for waf sync command
+func funcWafSync(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
+		return executor.Runner.SyncIPSets(out)
+	})
+}
+
+// Export WAF IP sets to file
+func NewCmdWafExport() *cobra.Command {
+	return builder.NewCmd("export").
+		WithDescription("Export addresses of WAF IP sets in the file format of sync").
+		SetFlags().
+		RunWithArgsAndCmd(funcWafExport)
+}
+
+// Function for waf export command
+func funcWafExport(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
+		return executor.Runner.ExportIPSets(out)
+	})
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/executor"
+)
+
+// Clear clipboard in background after copying credentials
+func NewClearClipboardCommand() *cobra.Command {
+	cmd := builder.NewCmd(constants.ClearClipboardCommand).
+		WithDescription("clear clipboard after timeout").
+		RunWithArgs(funcClearClipboard)
+	cmd.Hidden = true
+
+	return cmd
+}
+
+// funcClearClipboard
+func funcClearClipboard(ctx context.Context, _ io.Writer, args []string) error {
+	return executor.RunExecutorWithoutCheckingConfig(ctx, func(executor executor.Executor) error {
+		return executor.Runner.ClearClipboard(ctx, args)
+	})
+}
@@ -61,20 +61,27 @@ func NewRootCommand(out, stderr io.Writer) *cobra.Command {
 			Message: "commands related to aws IAM credentials",
 			Commands: []*cobra.Command{
 				NewRenewCredentialsCommand(),
+				NewVaultCommand(),
+				NewMFACommand(),
 			},
 		},
 		{
 			Message: "commands for controlling assume role",
 			Commands: []*cobra.Command{
 				NewSetupCommand(),
+				NewExecCommand(),
+				NewCredentialProcessCommand(),
+				NewServeCommand(),
+				NewConsoleCommand(),
 				NewWhoCommand(),
 			},
 		},
 		{
-			Message: "commands for retrieving information related to AWS WAF.",
+			Message: "commands related to AWS WAF.",
 			Commands: []*cobra.Command{
 				NewCmdDescribeWebACL(),
 				NewCmdHasIP(),
+				NewWafCommand(),
 			},
 		},
 	}
@@ -86,6 +93,9 @@ func NewRootCommand(out, stderr io.Writer) *cobra.Command {
 	rootCmd.AddCommand(NewCmdCompletion())
 	rootCmd.AddCommand(NewVersionCommand())
 	rootCmd.AddCommand(NewEcrLoginCommand())
+	rootCmd.AddCommand(NewLoadtestCommand())
+	rootCmd.AddCommand(NewMaintenanceCommand())
+	rootCmd.AddCommand(NewClearClipboardCommand())
 
 	rootCmd.PersistentFlags().StringVarP(&v, "verbosity", "v", constants.DefaultLogLevel.String(), "Log level (debug, info, warn, error, fatal, panic)")
 
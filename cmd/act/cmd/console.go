@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/executor"
+)
+
+// Sign in to AWS console with assume role
+func NewConsoleCommand() *cobra.Command {
+	return builder.NewCmd("console").
+		WithDescription("create sign-in URL of AWS console for environment").
+		WithLongDescription("create sign-in URL of AWS console for environment\n\nusage: act console [env] [--service ec2] [--region us-east-1] [--open|--raw-output]").
+		SetFlags().
+		RunWithArgsAndCmd(funcConsole)
+}
+
+// funcConsole
+func funcConsole(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmd.Help()
+	}
+
+	return executor.RunExecutor(ctx, constants.NeedExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.Console(out, args)
+	})
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/executor"
+)
+
+// Print credentials for AWS CLI credential_process
+func NewCredentialProcessCommand() *cobra.Command {
+	return builder.NewCmd("credential-process").
+		WithDescription("print assume credentials for credential_process of AWS CLI and SDKs").
+		WithLongDescription("print assume credentials for credential_process of AWS CLI and SDKs\n\n" +
+			"[profile prod]\ncredential_process = act credential-process prod").
+		SetFlags().
+		RunWithArgs(funcCredentialProcess)
+}
+
+// funcCredentialProcess
+func funcCredentialProcess(ctx context.Context, out io.Writer, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: act credential-process [env]")
+	}
+
+	// access key is not checked, because credential_process is run on every call of AWS CLI and SDKs
+	return executor.RunExecutor(ctx, constants.SkipExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.CredentialProcess(out, args)
+	})
+}
@@ -14,13 +14,18 @@ import (
 func NewEcrLoginCommand() *cobra.Command {
 	return builder.NewCmd("ecr-login").
 		WithDescription("login to ECR").
+		WithLongDescription("login to ECR\n\nusage: act ecr-login [env]").
 		SetFlags().
-		RunWithNoArgs(funcEcrLogin)
+		RunWithArgsAndCmd(funcEcrLogin)
 }
 
 // funcEcrLogin
-func funcEcrLogin(ctx context.Context, out io.Writer) error {
+func funcEcrLogin(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmd.Help()
+	}
+
 	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
-		return executor.Runner.EcrLogin(out)
+		return executor.Runner.EcrLogin(out, args)
 	})
 }
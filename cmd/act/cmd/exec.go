@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/executor"
+)
+
+// Execute command with assume role credentials
+func NewExecCommand() *cobra.Command {
+	return builder.NewCmd("exec").
+		WithDescription("run command with assume credentials of environment").
+		WithLongDescription("run command with assume credentials of environment\n\nusage: act exec [env] -- [command]").
+		SetFlags().
+		RunWithArgsAndCmd(funcExec)
+}
+
+// funcExec
+func funcExec(ctx context.Context, _ io.Writer, cmd *cobra.Command, args []string) error {
+	if cmd.ArgsLenAtDash() != 1 {
+		return errors.New("usage: act exec [env] -- [command]")
+	}
+
+	return executor.RunExecutor(ctx, constants.NeedExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.Exec(ctx, args)
+	})
+}
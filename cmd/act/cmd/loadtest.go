@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/child"
+)
+
+// Command related to loadtest environment
+func NewLoadtestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "loadtest",
+		Short: "start or stop loadtest environment",
+	}
+
+	cmd.AddCommand(child.NewCmdLoadtestStart())
+	cmd.AddCommand(child.NewCmdLoadtestStop())
+	cmd.AddCommand(child.NewCmdLoadtestStatus())
+	return cmd
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/child"
+)
+
+// Command related to maintenance mode of load balancers
+func NewMaintenanceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "maintenance",
+		Short: "turn on or off maintenance mode of load balancers",
+	}
+
+	cmd.AddCommand(child.NewCmdMaintenanceOn())
+	cmd.AddCommand(child.NewCmdMaintenanceOff())
+	cmd.AddCommand(child.NewCmdMaintenanceStatus())
+	cmd.AddCommand(child.NewCmdMaintenanceSchedule())
+	return cmd
+}
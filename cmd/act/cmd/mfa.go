@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/child"
+)
+
+// Command related to MFA session
+func NewMFACommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mfa",
+		Short: "manage MFA session used for assuming roles",
+	}
+
+	cmd.AddCommand(child.NewCmdMFAStatus())
+	cmd.AddCommand(child.NewCmdMFALogout())
+	return cmd
+}
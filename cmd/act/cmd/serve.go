@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/executor"
+)
+
+// Serve assume credentials with local credential server
+func NewServeCommand() *cobra.Command {
+	return builder.NewCmd("serve").
+		WithDescription("serve assume credentials with local ECS compatible endpoint").
+		SetFlags().
+		RunWithArgsAndCmd(funcServe)
+}
+
+// funcServe
+func funcServe(ctx context.Context, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmd.Help()
+	}
+
+	// access key is not checked, because credentials are served for every request of AWS SDKs
+	return executor.RunExecutor(ctx, constants.SkipExpiredCheck, func(executor executor.Executor) error {
+		return executor.Runner.Serve(ctx, out, args)
+	})
+}
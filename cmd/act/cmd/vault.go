@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/child"
+)
+
+// Command related to credential vault
+func NewVaultCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vault",
+		Short: "manage credentials cached in the vault",
+	}
+
+	cmd.AddCommand(child.NewCmdVaultList())
+	cmd.AddCommand(child.NewCmdVaultRemove())
+	cmd.AddCommand(child.NewCmdVaultClear())
+	return cmd
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/DevopsArtFactory/act/cmd/act/cmd/builder"
+	"github.com/DevopsArtFactory/act/cmd/act/cmd/child"
 	"github.com/DevopsArtFactory/act/pkg/executor"
 )
 
@@ -44,13 +45,24 @@ func NewCmdHasIP() *cobra.Command {
 
 // Function for has-ip command
 func funcHasIP(ctx context.Context, out io.Writer, args []string) error {
-	if len(args) != 1 {
-		return errors.New("usage: act has-ip [IP1] [IP2]... ")
-	}
 	return executor.RunExecutorConfigReadOnly(ctx, func(executor executor.Executor) error {
-		if err := executor.Runner.HasIP(out, args); err != nil {
+		if err := executor.Runner.HasIP(ctx, out, args); err != nil {
 			logrus.Errorf(err.Error())
 		}
 		return nil
 	})
 }
+
+// Command for managing AWS WAF
+func NewWafCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "waf",
+		Short: "manage IP sets of AWS WAF",
+	}
+
+	cmd.AddCommand(child.NewCmdWafAddIP())
+	cmd.AddCommand(child.NewCmdWafRemoveIP())
+	cmd.AddCommand(child.NewCmdWafSync())
+	cmd.AddCommand(child.NewCmdWafExport())
+	return cmd
+}
@@ -9,11 +9,16 @@ import (
 
 	"github.com/DevopsArtFactory/act/cmd/act/app"
 	"github.com/DevopsArtFactory/act/pkg/color"
+	"github.com/DevopsArtFactory/act/pkg/runner"
 )
 
 func main() {
 	if err := app.Run(os.Stdout, os.Stderr); err != nil {
-		if errors.Is(err, context.Canceled) {
+		var exitErr *runner.ExitError
+		if errors.As(err, &exitErr) {
+			// pass through exit code of the command run by `act exec`
+			os.Exit(exitErr.Code())
+		} else if errors.Is(err, context.Canceled) {
 			Logger.Debugln("ignore error since context is cancelled:", err)
 		} else {
 			color.Red.Fprintln(os.Stderr, err)
@@ -24,7 +24,7 @@ func Parse(args []string) (*Argument, error) {
 
 // IsValid checks if args is valid or not
 func IsValid(args []string) bool {
-	return len(args) >= 3
+	return len(args) >= 2
 }
 
 // parseArgument parsing arguments
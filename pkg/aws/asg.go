@@ -1,12 +1,17 @@
 package aws
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+	"github.com/DevopsArtFactory/act/pkg/tools"
 )
 
 func GetASGClientFn(sess client.ConfigProvider, region string, creds *credentials.Credentials) *autoscaling.AutoScaling {
@@ -16,32 +21,34 @@ func GetASGClientFn(sess client.ConfigProvider, region string, creds *credential
 	return autoscaling.New(sess, &aws.Config{Region: aws.String(region), Credentials: creds})
 }
 
+// GetExactASGNames returns names of autoscaling groups which contain the pattern
 func (c Client) GetExactASGNames(asgName string) ([]*string, error) {
 	var response []*string
 	input := &autoscaling.DescribeAutoScalingGroupsInput{
 		MaxRecords: aws.Int64(100),
 	}
 
-	result, err := c.ASGClient.DescribeAutoScalingGroups(input)
+	err := c.ASGClient.DescribeAutoScalingGroupsPages(input, func(page *autoscaling.DescribeAutoScalingGroupsOutput, lastPage bool) bool {
+		for _, v := range page.AutoScalingGroups {
+			if strings.Contains(*v.AutoScalingGroupName, asgName) {
+				response = append(response, v.AutoScalingGroupName)
+			}
+		}
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range result.AutoScalingGroups {
-		if strings.Contains(*v.AutoScalingGroupName, asgName) {
-			response = append(response, v.AutoScalingGroupName)
-		}
-	}
-
 	return response, nil
 }
 
-func (c Client) GetLoadtestASGStatus(asgName *string) (*int64, error) {
+// DescribeASG returns the autoscaling group
+func (c Client) DescribeASG(asgName *string) (*autoscaling.Group, error) {
 	input := &autoscaling.DescribeAutoScalingGroupsInput{
 		AutoScalingGroupNames: []*string{
 			asgName,
 		},
-		MaxRecords: aws.Int64(100),
 	}
 
 	result, err := c.ASGClient.DescribeAutoScalingGroups(input)
@@ -49,15 +56,103 @@ func (c Client) GetLoadtestASGStatus(asgName *string) (*int64, error) {
 		return nil, err
 	}
 
-	return result.AutoScalingGroups[0].DesiredCapacity, nil
+	if len(result.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("autoscaling group does not exist: %s", *asgName)
+	}
+
+	return result.AutoScalingGroups[0], nil
+}
+
+// GetLoadtestASGStatus returns status of autoscaling group, ready means instances are started or stopped as expected
+func (c Client) GetLoadtestASGStatus(asgName *string, started bool) (schema.LoadtestStatus, error) {
+	group, err := c.DescribeASG(asgName)
+	if err != nil {
+		return schema.LoadtestStatus{}, err
+	}
+
+	return schema.LoadtestStatus{
+		Type: "asg",
+		Name: aws.StringValue(asgName),
+		Status: fmt.Sprintf("desired %d, in service %d (min %d, max %d)",
+			aws.Int64Value(group.DesiredCapacity), CountInServiceInstances(group), aws.Int64Value(group.MinSize), aws.Int64Value(group.MaxSize)),
+		Ready: isASGReady(group, started),
+	}, nil
+}
+
+// isASGReady checks if all desired instances are in service, or no instance is left if it is stopped
+// Group started with desired capacity 0 is ready without instances.
+func isASGReady(group *autoscaling.Group, started bool) bool {
+	if !started {
+		return len(group.Instances) == 0
+	}
+
+	return CountInServiceInstances(group) >= aws.Int64Value(group.DesiredCapacity)
+}
+
+// CountInServiceInstances returns the number of instances which are in service
+func CountInServiceInstances(group *autoscaling.Group) int64 {
+	var count int64
+	for _, instance := range group.Instances {
+		if aws.StringValue(instance.LifecycleState) == autoscaling.LifecycleStateInService {
+			count++
+		}
+	}
+
+	return count
+}
+
+// GetASGCapacity returns current capacity and suspended processes of autoscaling group
+func GetASGCapacity(group *autoscaling.Group) schema.ASGCapacity {
+	// suspended processes are always set, so that processes resumed in snapshot are also restored
+	capacity := schema.ASGCapacity{
+		MinSize:            aws.Int64Value(group.MinSize),
+		DesiredCapacity:    aws.Int64Value(group.DesiredCapacity),
+		MaxSize:            aws.Int64Value(group.MaxSize),
+		SuspendedProcesses: []string{},
+	}
+
+	for _, process := range group.SuspendedProcesses {
+		capacity.SuspendedProcesses = append(capacity.SuspendedProcesses, aws.StringValue(process.ProcessName))
+	}
+
+	return capacity
+}
+
+// GetASGTag returns value of tag in autoscaling group
+func GetASGTag(group *autoscaling.Group, key string) (string, bool) {
+	for _, tag := range group.Tags {
+		if aws.StringValue(tag.Key) == key {
+			return aws.StringValue(tag.Value), true
+		}
+	}
+
+	return constants.EmptyString, false
+}
+
+// SetASGTag creates or updates tag of autoscaling group
+func (c Client) SetASGTag(asgName *string, key, value string) error {
+	input := &autoscaling.CreateOrUpdateTagsInput{
+		Tags: []*autoscaling.Tag{
+			{
+				ResourceId:        asgName,
+				ResourceType:      aws.String("auto-scaling-group"),
+				Key:               aws.String(key),
+				Value:             aws.String(value),
+				PropagateAtLaunch: aws.Bool(false),
+			},
+		},
+	}
+
+	_, err := c.ASGClient.CreateOrUpdateTags(input)
+	return err
 }
 
-func (c Client) updateLoadtestASG(asgName *string, capacity int64) error {
+func (c Client) updateLoadtestASG(asgName *string, capacity schema.ASGCapacity) error {
 	input := &autoscaling.UpdateAutoScalingGroupInput{
 		AutoScalingGroupName: asgName,
-		MinSize:              aws.Int64(capacity),
-		DesiredCapacity:      aws.Int64(capacity),
-		MaxSize:              aws.Int64(capacity),
+		MinSize:              aws.Int64(capacity.MinSize),
+		DesiredCapacity:      aws.Int64(capacity.DesiredCapacity),
+		MaxSize:              aws.Int64(capacity.MaxSize),
 	}
 
 	_, err := c.ASGClient.UpdateAutoScalingGroup(input)
@@ -68,10 +163,51 @@ func (c Client) updateLoadtestASG(asgName *string, capacity int64) error {
 	return nil
 }
 
-func (c Client) StartLoadtestASG(asgName *string) error {
-	return c.updateLoadtestASG(asgName, 1)
+// setSuspendedProcesses suspends processes in the list and resumes the others
+// Processes are not changed if the list is nil, which means it is not set in override or snapshot.
+func (c Client) setSuspendedProcesses(group *autoscaling.Group, processes []string) error {
+	if processes == nil {
+		return nil
+	}
+
+	var resume []*string
+	for _, process := range group.SuspendedProcesses {
+		if !tools.IsStringInArray(aws.StringValue(process.ProcessName), processes) {
+			resume = append(resume, process.ProcessName)
+		}
+	}
+
+	if len(resume) > 0 {
+		if _, err := c.ASGClient.ResumeProcesses(&autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: group.AutoScalingGroupName,
+			ScalingProcesses:     resume,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(processes) > 0 {
+		if _, err := c.ASGClient.SuspendProcesses(&autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: group.AutoScalingGroupName,
+			ScalingProcesses:     aws.StringSlice(processes),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StartLoadtestASG restores capacity and suspended processes of autoscaling group
+func (c Client) StartLoadtestASG(group *autoscaling.Group, capacity schema.ASGCapacity) error {
+	if err := c.updateLoadtestASG(group.AutoScalingGroupName, capacity); err != nil {
+		return err
+	}
+
+	return c.setSuspendedProcesses(group, capacity.SuspendedProcesses)
 }
 
+// StopLoadtestASG scales autoscaling group to zero
 func (c Client) StopLoadtestASG(asgName *string) error {
-	return c.updateLoadtestASG(asgName, 0)
+	return c.updateLoadtestASG(asgName, schema.ASGCapacity{})
 }
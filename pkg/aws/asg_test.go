@@ -0,0 +1,33 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+func TestIsASGReady(t *testing.T) {
+	inService := &autoscaling.Instance{LifecycleState: aws.String(autoscaling.LifecycleStateInService)}
+	pending := &autoscaling.Instance{LifecycleState: aws.String(autoscaling.LifecycleStatePending)}
+
+	testData := []struct {
+		desired   int64
+		instances []*autoscaling.Instance
+		started   bool
+		expected  bool
+	}{
+		{desired: 2, instances: []*autoscaling.Instance{inService, inService}, started: true, expected: true},
+		{desired: 2, instances: []*autoscaling.Instance{inService, pending}, started: true, expected: false},
+		{desired: 0, started: true, expected: true},
+		{desired: 0, instances: []*autoscaling.Instance{inService}, started: false, expected: false},
+		{desired: 0, started: false, expected: true},
+	}
+
+	for _, td := range testData {
+		group := &autoscaling.Group{DesiredCapacity: aws.Int64(td.desired), Instances: td.instances}
+		if output := isASGReady(group, td.started); output != td.expected {
+			t.Errorf("desired %d, %d instances, started %t: expected %t, output %t", td.desired, len(td.instances), td.started, td.expected, output)
+		}
+	}
+}
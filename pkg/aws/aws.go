@@ -15,32 +15,42 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/aws/aws-sdk-go/service/wafregional"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
 )
 
 const PORT = 3310
 
 type Client struct {
-	RDSClient *rds.RDS
-	STSClient *sts.STS
-	S3Client  *s3.S3
-	WafClient *waf.WAF
-	ECRClient *ecr.ECR
-	IAMClient *iam.IAM
-	ELBClient *elbv2.ELBV2
-	ASGClient *autoscaling.AutoScaling
-	Region    string
+	RDSClient         *rds.RDS
+	STSClient         *sts.STS
+	S3Client          *s3.S3
+	WafClient         *waf.WAF
+	WafRegionalClient *wafregional.WAFRegional
+	WafV2Client       *wafv2.WAFV2
+	WafV2GlobalClient *wafv2.WAFV2
+	ECRClient         *ecr.ECR
+	IAMClient         *iam.IAM
+	ELBClient         *elbv2.ELBV2
+	ASGClient         *autoscaling.AutoScaling
+	Region            string
 }
 
 func NewClient(sess client.ConfigProvider, region string, creds *credentials.Credentials) Client {
 	return Client{
-		RDSClient: GetRDSClientFn(sess, region, creds),
-		STSClient: GetSTSClientFn(sess, region, creds),
-		S3Client:  GetS3ClientFn(sess, region, creds),
-		WafClient: GetWafClientFn(sess, region, creds),
-		IAMClient: GetIAMClientFn(sess, creds),
-		ELBClient: GetELBClientFn(sess, region, creds),
-		ECRClient: GetEcrClientFn(sess, region, creds),
-		ASGClient: GetASGClientFn(sess, region, creds),
+		RDSClient:         GetRDSClientFn(sess, region, creds),
+		STSClient:         GetSTSClientFn(sess, region, creds),
+		S3Client:          GetS3ClientFn(sess, region, creds),
+		WafClient:         GetWafClientFn(sess, region, creds),
+		WafRegionalClient: GetWafRegionalClientFn(sess, region, creds),
+		WafV2Client:       GetWafV2ClientFn(sess, region, creds),
+		WafV2GlobalClient: GetWafV2ClientFn(sess, constants.CloudFrontRegion, creds),
+		IAMClient:         GetIAMClientFn(sess, creds),
+		ELBClient:         GetELBClientFn(sess, region, creds),
+		ECRClient:         GetEcrClientFn(sess, region, creds),
+		ASGClient:         GetASGClientFn(sess, region, creds),
 	}
 }
 
@@ -1,7 +1,8 @@
 package aws
 
 import (
-	"encoding/json"
+	"fmt"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client"
@@ -19,76 +20,280 @@ func GetELBClientFn(sess client.ConfigProvider, region string, creds *credential
 	return elbv2.New(sess, &aws.Config{Region: aws.String(region), Credentials: creds})
 }
 
+// DescribeListeners returns all listeners of load balancer
 func (c Client) DescribeListeners(loadbalancerArn string) ([]*elbv2.Listener, error) {
+	var listeners []*elbv2.Listener
 	input := &elbv2.DescribeListenersInput{
 		LoadBalancerArn: aws.String(loadbalancerArn),
 	}
 
-	result, err := c.ELBClient.DescribeListeners(input)
+	err := c.ELBClient.DescribeListenersPages(input, func(page *elbv2.DescribeListenersOutput, lastPage bool) bool {
+		listeners = append(listeners, page.Listeners...)
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return result.Listeners, nil
+	return listeners, nil
 }
 
-// CreateMaintenanceRule creates maintenance rule
-func (c Client) CreateMaintenanceRule(listenerArn *string, message string) error {
-	maintenanceMessage, _ := json.Marshal(schema.MaintenanceMessage{
-		Timestamp: 1565934483777,
-		Code:      -99999,
-		Message:   message,
-	})
+// BuildMaintenanceAction returns fixed-response action of maintenance
+func BuildMaintenanceAction(response schema.MaintenanceResponse) *elbv2.Action {
+	return &elbv2.Action{
+		FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+			ContentType: aws.String(response.ContentType),
+			MessageBody: aws.String(response.Body),
+			StatusCode:  aws.String(response.StatusCode),
+		},
+		Type: aws.String("fixed-response"),
+	}
+}
 
-	fixedResponseActionConfig := &elbv2.FixedResponseActionConfig{
-		ContentType: aws.String("application/json"),
-		MessageBody: aws.String(string(maintenanceMessage)),
-		StatusCode:  aws.String("503"),
+// BuildMaintenanceConditions returns conditions of maintenance rule, all paths are matched if paths are empty
+func BuildMaintenanceConditions(response schema.MaintenanceResponse) []*elbv2.RuleCondition {
+	conditions := BuildScopeConditions(response.Hosts, response.Paths)
+	if len(response.Paths) == 0 {
+		conditions = append(conditions, &elbv2.RuleCondition{
+			Field: aws.String("path-pattern"),
+			Values: []*string{
+				aws.String("/*"),
+			},
+		})
 	}
 
-	action := &elbv2.Action{
-		FixedResponseConfig: fixedResponseActionConfig,
-		Type:                aws.String("fixed-response"),
+	return conditions
+}
+
+// BuildScopeConditions returns host-header and path-pattern conditions which limit the scope of maintenance
+func BuildScopeConditions(hosts, paths []string) []*elbv2.RuleCondition {
+	var conditions []*elbv2.RuleCondition
+	if len(hosts) > 0 {
+		conditions = append(conditions, &elbv2.RuleCondition{
+			Field: aws.String("host-header"),
+			HostHeaderConfig: &elbv2.HostHeaderConditionConfig{
+				Values: aws.StringSlice(hosts),
+			},
+		})
 	}
 
-	input := &elbv2.CreateRuleInput{
-		Actions: []*elbv2.Action{action},
-		Conditions: []*elbv2.RuleCondition{
-			{
-				Field: aws.String("path-pattern"),
-				Values: []*string{
-					aws.String("/*"),
-				},
+	if len(paths) > 0 {
+		conditions = append(conditions, &elbv2.RuleCondition{
+			Field: aws.String("path-pattern"),
+			PathPatternConfig: &elbv2.PathPatternConditionConfig{
+				Values: aws.StringSlice(paths),
 			},
-		},
-		ListenerArn: listenerArn,
-		Priority:    aws.Int64(constants.DefaultMaintenancePriority),
+		})
+	}
+
+	return conditions
+}
+
+// BuildBypassConditions returns conditions of each bypass rule, which have the same scope as maintenance rule
+// Values of conditions in a rule cannot be more than 5, because it is the limit of listener rule.
+func BuildBypassConditions(bypass schema.MaintenanceBypass, hosts, paths []string) ([][]*elbv2.RuleCondition, error) {
+	limit := constants.MaxConditionValues - len(hosts) - len(paths)
+	if limit <= 0 && (len(bypass.CIDRs) > 0 || len(bypass.Headers) > 0) {
+		return nil, fmt.Errorf("bypass rule cannot be created, because hosts and paths have %d values", len(hosts)+len(paths))
 	}
 
-	_, err := c.ELBClient.CreateRule(input)
+	var rules [][]*elbv2.RuleCondition
+	for _, values := range chunkValues(bypass.CIDRs, limit) {
+		rules = append(rules, append(BuildScopeConditions(hosts, paths), &elbv2.RuleCondition{
+			Field: aws.String("source-ip"),
+			SourceIpConfig: &elbv2.SourceIpConditionConfig{
+				Values: aws.StringSlice(values),
+			},
+		}))
+	}
+
+	for _, header := range bypass.Headers {
+		for _, values := range chunkValues(header.Values, limit) {
+			rules = append(rules, append(BuildScopeConditions(hosts, paths), &elbv2.RuleCondition{
+				Field: aws.String("http-header"),
+				HttpHeaderConfig: &elbv2.HttpHeaderConditionConfig{
+					HttpHeaderName: aws.String(header.Name),
+					Values:         aws.StringSlice(values),
+				},
+			}))
+		}
+	}
+
+	return rules, nil
+}
+
+// chunkValues splits values by the limit of condition values
+func chunkValues(values []string, limit int) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(values); start += limit {
+		end := start + limit
+		if end > len(values) {
+			end = len(values)
+		}
+		chunks = append(chunks, values[start:end])
+	}
+
+	return chunks
+}
+
+// PlanMaintenanceOn returns changes which put listener into maintenance
+// Bypass rules and maintenance rule should have higher priority than all other rules, and rules left by act are recreated.
+func PlanMaintenanceOn(listener *elbv2.Listener, rules []*elbv2.Rule, tagged []TaggedRule, bypass schema.MaintenanceBypass, response schema.MaintenanceResponse) (RulePlan, error) {
+	plan := PlanMaintenanceOff(listener, tagged)
+
+	bypassConditions, err := BuildBypassConditions(bypass, response.Hosts, response.Paths)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	var preferred []int64
+	for i := range bypassConditions {
+		preferred = append(preferred, int64(constants.DefaultBypassPriority+i))
+	}
+	preferred = append(preferred, constants.DefaultMaintenancePriority)
+
+	// rules created by act are recreated, so their priorities are free
+	exclude := map[string]bool{}
+	for _, rule := range tagged {
+		exclude[*rule.Rule.RuleArn] = true
+	}
+
+	used, first := UsedPriorities(rules, exclude)
+	limit := int64(constants.MaxRulePriority + 1)
+	if first != nil {
+		limit, _ = strconv.ParseInt(*first.Priority, 10, 64)
+	}
+
+	priorities, err := AllocatePriorities(used, preferred, limit)
+	if err != nil {
+		if first != nil {
+			return nil, fmt.Errorf("%s: priority conflicts with rule %s: %s", DescribeListener(listener), *first.RuleArn, err.Error())
+		}
+		return nil, fmt.Errorf("%s: %s", DescribeListener(listener), err.Error())
+	}
+
+	for i, conditions := range bypassConditions {
+		plan = append(plan, RuleChange{
+			Change:     constants.CreateRuleChange,
+			Listener:   listener,
+			Type:       constants.BypassRuleType,
+			Priority:   priorities[i],
+			Conditions: conditions,
+			Actions:    listener.DefaultActions,
+		})
+	}
+
+	return append(plan, RuleChange{
+		Change:     constants.CreateRuleChange,
+		Listener:   listener,
+		Type:       constants.MaintenanceRuleType,
+		Priority:   priorities[len(priorities)-1],
+		Conditions: BuildMaintenanceConditions(response),
+		Actions:    []*elbv2.Action{BuildMaintenanceAction(response)},
+	}), nil
+}
+
+// PlanMaintenanceOff returns changes which delete rules created by act, maintenance rule is deleted before bypass rules
+func PlanMaintenanceOff(listener *elbv2.Listener, tagged []TaggedRule) RulePlan {
+	var plan RulePlan
+	for _, ruleType := range []string{constants.MaintenanceRuleType, constants.BypassRuleType} {
+		for _, rule := range tagged {
+			if rule.Type == ruleType {
+				plan = append(plan, NewDeleteRuleChange(listener, rule))
+			}
+		}
+	}
+
+	for _, rule := range tagged {
+		if rule.Type != constants.MaintenanceRuleType && rule.Type != constants.BypassRuleType {
+			plan = append(plan, NewDeleteRuleChange(listener, rule))
+		}
+	}
+
+	return plan
 }
 
 // DescribeRules describes list of rules in listener
 func (c Client) DescribeRules(listenerArn *string) ([]*elbv2.Rule, error) {
+	var rules []*elbv2.Rule
 	input := &elbv2.DescribeRulesInput{
 		ListenerArn: listenerArn,
 	}
 
-	result, err := c.ELBClient.DescribeRules(input)
+	for {
+		result, err := c.ELBClient.DescribeRules(input)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, result.Rules...)
+
+		if result.NextMarker == nil {
+			break
+		}
+		input.Marker = result.NextMarker
+	}
+
+	return rules, nil
+}
+
+// TaggedRule is a listener rule created by act with the type in tag
+type TaggedRule struct {
+	Rule *elbv2.Rule
+	Type string
+}
+
+// GetMaintenanceRules returns rules in listener which are created by act
+func (c Client) GetMaintenanceRules(listenerArn *string) ([]TaggedRule, error) {
+	rules, err := c.DescribeRules(listenerArn)
 	if err != nil {
 		return nil, err
 	}
 
-	return result.Rules, nil
+	return c.GetTaggedRules(rules)
+}
+
+// GetTaggedRules returns rules which have the tag of act
+func (c Client) GetTaggedRules(rules []*elbv2.Rule) ([]TaggedRule, error) {
+	candidates := map[string]*elbv2.Rule{}
+	var arns []*string
+	for _, rule := range rules {
+		if aws.BoolValue(rule.IsDefault) {
+			continue
+		}
+		candidates[*rule.RuleArn] = rule
+		arns = append(arns, rule.RuleArn)
+	}
+
+	var maintenanceRules []TaggedRule
+	for start := 0; start < len(arns); start += constants.MaxELBDescribeTags {
+		end := start + constants.MaxELBDescribeTags
+		if end > len(arns) {
+			end = len(arns)
+		}
+
+		result, err := c.ELBClient.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: arns[start:end]})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, desc := range result.TagDescriptions {
+			for _, tag := range desc.Tags {
+				if aws.StringValue(tag.Key) == constants.MaintenanceTag {
+					maintenanceRules = append(maintenanceRules, TaggedRule{
+						Rule: candidates[*desc.ResourceArn],
+						Type: aws.StringValue(tag.Value),
+					})
+					break
+				}
+			}
+		}
+	}
+
+	return maintenanceRules, nil
 }
 
-// DeleteMaintenanceRule deletes maintenance rule
-func (c Client) DeleteMaintenanceRule(ruleArn *string) error {
+// DeleteRule deletes listener rule
+func (c Client) DeleteRule(ruleArn *string) error {
 	input := &elbv2.DeleteRuleInput{
 		RuleArn: ruleArn,
 	}
@@ -0,0 +1,241 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+)
+
+// RuleChange is a change of listener rule which will be applied
+type RuleChange struct {
+	Change     string
+	Listener   *elbv2.Listener
+	Rule       *elbv2.Rule
+	Type       string
+	Priority   int64
+	Conditions []*elbv2.RuleCondition
+	Actions    []*elbv2.Action
+}
+
+// RulePlan is the list of rule changes, which are applied in order
+type RulePlan []RuleChange
+
+// NewDeleteRuleChange returns change which deletes the rule created by act
+func NewDeleteRuleChange(listener *elbv2.Listener, rule TaggedRule) RuleChange {
+	priority, _ := strconv.ParseInt(aws.StringValue(rule.Rule.Priority), 10, 64)
+	return RuleChange{
+		Change:     constants.DeleteRuleChange,
+		Listener:   listener,
+		Rule:       rule.Rule,
+		Type:       rule.Type,
+		Priority:   priority,
+		Conditions: rule.Rule.Conditions,
+		Actions:    rule.Rule.Actions,
+	}
+}
+
+// String returns a line of diff
+func (c RuleChange) String() string {
+	sign := "+"
+	if c.Change == constants.DeleteRuleChange {
+		sign = "-"
+	}
+
+	var actions []string
+	for _, action := range c.Actions {
+		actions = append(actions, aws.StringValue(action.Type))
+	}
+
+	return fmt.Sprintf("%s %s %s rule, priority %d: %s -> %s", sign, DescribeListener(c.Listener), c.Type, c.Priority,
+		DescribeConditions(c.Conditions), strings.Join(actions, ","))
+}
+
+// Creates returns the number of rules which will be created
+func (p RulePlan) Creates() int {
+	count := 0
+	for _, change := range p {
+		if change.Change == constants.CreateRuleChange {
+			count++
+		}
+	}
+	return count
+}
+
+// Deletes returns the number of rules which will be deleted
+func (p RulePlan) Deletes() int {
+	return len(p) - p.Creates()
+}
+
+// DescribeConditions returns short description of rule conditions
+func DescribeConditions(conditions []*elbv2.RuleCondition) string {
+	var desc []string
+	for _, condition := range conditions {
+		var values []*string
+		switch {
+		case condition.SourceIpConfig != nil:
+			values = condition.SourceIpConfig.Values
+		case condition.HostHeaderConfig != nil:
+			values = condition.HostHeaderConfig.Values
+		case condition.PathPatternConfig != nil:
+			values = condition.PathPatternConfig.Values
+		case condition.HttpHeaderConfig != nil:
+			values = condition.HttpHeaderConfig.Values
+			desc = append(desc, fmt.Sprintf("%s[%s]=%s", aws.StringValue(condition.Field),
+				aws.StringValue(condition.HttpHeaderConfig.HttpHeaderName), strings.Join(aws.StringValueSlice(values), ",")))
+			continue
+		default:
+			values = condition.Values
+		}
+		desc = append(desc, fmt.Sprintf("%s=%s", aws.StringValue(condition.Field), strings.Join(aws.StringValueSlice(values), ",")))
+	}
+
+	return strings.Join(desc, " ")
+}
+
+// UsedPriorities returns priorities of rules except default rule and excluded rules, with the rule of the lowest priority value
+func UsedPriorities(rules []*elbv2.Rule, exclude map[string]bool) (map[int64]bool, *elbv2.Rule) {
+	used := map[int64]bool{}
+	var first *elbv2.Rule
+	var firstPriority int64
+	for _, rule := range rules {
+		if aws.BoolValue(rule.IsDefault) || exclude[aws.StringValue(rule.RuleArn)] {
+			continue
+		}
+
+		priority, err := strconv.ParseInt(aws.StringValue(rule.Priority), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		used[priority] = true
+		if first == nil || priority < firstPriority {
+			first, firstPriority = rule, priority
+		}
+	}
+
+	return used, first
+}
+
+// AllocatePriorities returns free priorities lower than limit in the order of preferred priorities
+// Preferred priorities are used if all of them are free, otherwise the lowest free priorities are allocated.
+func AllocatePriorities(used map[int64]bool, preferred []int64, limit int64) ([]int64, error) {
+	if limit > constants.MaxRulePriority+1 {
+		limit = constants.MaxRulePriority + 1
+	}
+
+	available := true
+	for i, priority := range preferred {
+		if priority < 1 || priority >= limit || used[priority] || (i > 0 && priority <= preferred[i-1]) {
+			available = false
+			break
+		}
+	}
+
+	if available {
+		return preferred, nil
+	}
+
+	var priorities []int64
+	for priority := int64(1); priority < limit && len(priorities) < len(preferred); priority++ {
+		if !used[priority] {
+			priorities = append(priorities, priority)
+		}
+	}
+
+	if len(priorities) < len(preferred) {
+		return nil, fmt.Errorf("%d rules need priorities lower than %d, but only %d priorities are free", len(preferred), limit, len(priorities))
+	}
+
+	return priorities, nil
+}
+
+// ApplyRulePlan applies changes of the plan in order
+func (c Client) ApplyRulePlan(plan RulePlan) error {
+	for _, change := range plan {
+		switch change.Change {
+		case constants.DeleteRuleChange:
+			if err := c.DeleteRule(change.Rule.RuleArn); err != nil {
+				return fmt.Errorf("failed to delete %s rule %s: %w", change.Type, *change.Rule.RuleArn, err)
+			}
+			logrus.Infof("%s rule is deleted: %s", change.Type, *change.Rule.RuleArn)
+		case constants.CreateRuleChange:
+			if err := c.createTaggedRule(change.Listener.ListenerArn, change.Priority, change.Conditions, change.Actions, change.Type); err != nil {
+				return fmt.Errorf("failed to create %s rule in %s: %w", change.Type, *change.Listener.ListenerArn, err)
+			}
+			logrus.Infof("%s rule is created with priority %d: %s", change.Type, change.Priority, *change.Listener.ListenerArn)
+		default:
+			return fmt.Errorf("unknown rule change: %s", change.Change)
+		}
+	}
+
+	return nil
+}
+
+// createTaggedRule creates listener rule with the tag of act
+func (c Client) createTaggedRule(listenerArn *string, priority int64, conditions []*elbv2.RuleCondition, actions []*elbv2.Action, ruleType string) error {
+	input := &elbv2.CreateRuleInput{
+		Actions:     actions,
+		Conditions:  conditions,
+		ListenerArn: listenerArn,
+		Priority:    aws.Int64(priority),
+	}
+
+	result, err := c.ELBClient.CreateRule(input)
+	if err != nil {
+		return err
+	}
+
+	// rule without tag cannot be found by act, so it is removed
+	if err := c.tagRule(result.Rules[0].RuleArn, ruleType); err != nil {
+		if delErr := c.DeleteRule(result.Rules[0].RuleArn); delErr != nil {
+			logrus.Errorf("failed to delete untagged rule %s: %s", *result.Rules[0].RuleArn, delErr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// tagRule adds the tag of act to listener rule
+func (c Client) tagRule(ruleArn *string, ruleType string) error {
+	input := &elbv2.AddTagsInput{
+		ResourceArns: []*string{ruleArn},
+		Tags: []*elbv2.Tag{
+			{
+				Key:   aws.String(constants.MaintenanceTag),
+				Value: aws.String(ruleType),
+			},
+		},
+	}
+
+	_, err := c.ELBClient.AddTags(input)
+	return err
+}
+
+// DescribeListener returns short description of listener
+func DescribeListener(listener *elbv2.Listener) string {
+	return fmt.Sprintf("%s:%d (%s)", aws.StringValue(listener.Protocol), aws.Int64Value(listener.Port), GetLoadBalancerName(aws.StringValue(listener.LoadBalancerArn)))
+}
+
+// GetLoadBalancerName returns name of load balancer from arn
+func GetLoadBalancerName(loadbalancerArn string) string {
+	parsed, err := arn.Parse(loadbalancerArn)
+	if err != nil {
+		return loadbalancerArn
+	}
+
+	// resource: loadbalancer/app/<name>/<id>
+	parts := strings.Split(parsed.Resource, "/")
+	if len(parts) < 3 {
+		return parsed.Resource
+	}
+
+	return parts[2]
+}
@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+func TestAllocatePriorities(t *testing.T) {
+	testData := []struct {
+		used      map[int64]bool
+		preferred []int64
+		limit     int64
+		expected  []int64
+	}{
+		{
+			used:      map[int64]bool{},
+			preferred: []int64{1, 2, 10},
+			limit:     50001,
+			expected:  []int64{1, 2, 10},
+		},
+		{
+			used:      map[int64]bool{5: true, 20: true},
+			preferred: []int64{1, 2, 10},
+			limit:     5,
+			expected:  []int64{1, 2, 3},
+		},
+		{
+			used:      map[int64]bool{1: true, 30: true},
+			preferred: []int64{1, 10},
+			limit:     30,
+			expected:  []int64{2, 3},
+		},
+		{
+			used:      map[int64]bool{2: true},
+			preferred: []int64{1, 10},
+			limit:     2,
+			expected:  nil,
+		},
+	}
+
+	for _, td := range testData {
+		output, err := AllocatePriorities(td.used, td.preferred, td.limit)
+		if td.expected == nil {
+			if err == nil {
+				t.Errorf("expected conflict error, output %v", output)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(output, td.expected) {
+			t.Errorf("expected %v, output %v", td.expected, output)
+		}
+	}
+}
+
+func TestUsedPriorities(t *testing.T) {
+	rules := []*elbv2.Rule{
+		{RuleArn: aws.String("default"), Priority: aws.String("default"), IsDefault: aws.Bool(true)},
+		{RuleArn: aws.String("act"), Priority: aws.String("10")},
+		{RuleArn: aws.String("api"), Priority: aws.String("20")},
+		{RuleArn: aws.String("web"), Priority: aws.String("15")},
+	}
+
+	used, first := UsedPriorities(rules, map[string]bool{"act": true})
+	if !reflect.DeepEqual(used, map[int64]bool{15: true, 20: true}) {
+		t.Errorf("wrong used priorities: %v", used)
+	}
+
+	if first == nil || *first.RuleArn != "web" {
+		t.Errorf("expected first rule web, output %v", first)
+	}
+}
+
+func TestGetLoadBalancerName(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "arn:aws:elasticloadbalancing:ap-northeast-2:123456789012:loadbalancer/app/api-external/50dc6c495c0c9188",
+			expected: "api-external",
+		},
+		{
+			input:    "api-external",
+			expected: "api-external",
+		},
+	}
+
+	for _, td := range testData {
+		if output := GetLoadBalancerName(td.input); output != td.expected {
+			t.Errorf("expected %s, output %s", td.expected, output)
+		}
+	}
+}
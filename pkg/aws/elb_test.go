@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestBuildBypassConditions(t *testing.T) {
+	bypass := schema.MaintenanceBypass{
+		CIDRs:   []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "1.1.1.1/32", "2.2.2.2/32", "2001:db8::/32"},
+		Headers: []schema.BypassHeader{{Name: "X-Maintenance-Bypass", Values: []string{"qa"}}},
+	}
+
+	rules, err := BuildBypassConditions(bypass, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rules) != 3 {
+		t.Fatalf("expected 3 rules, output %d", len(rules))
+	}
+
+	if len(rules[0][0].SourceIpConfig.Values) != 5 || len(rules[1][0].SourceIpConfig.Values) != 1 {
+		t.Errorf("source-ip values should be split by 5: %v", rules)
+	}
+
+	if aws.StringValue(rules[2][0].Field) != "http-header" || aws.StringValue(rules[2][0].HttpHeaderConfig.HttpHeaderName) != "X-Maintenance-Bypass" {
+		t.Errorf("wrong http-header condition: %v", rules[2])
+	}
+
+	// scope of maintenance reduces the number of values in a rule
+	rules, err = BuildBypassConditions(bypass, []string{"api.example.com"}, []string{"/v1/*", "/v2/*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rules) != 4 || len(rules[0]) != 3 || len(rules[0][2].SourceIpConfig.Values) != 2 {
+		t.Errorf("bypass rules should have scope conditions: %v", rules)
+	}
+
+	if _, err := BuildBypassConditions(bypass, []string{"a.example.com", "b.example.com"}, []string{"/a", "/b", "/c"}); err == nil {
+		t.Errorf("bypass rule without space for values should return error")
+	}
+}
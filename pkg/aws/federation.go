@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+)
+
+// partitionConsoles is the federation endpoint and console URL of each partition
+var partitionConsoles = map[string][2]string{
+	"aws":        {"https://signin.aws.amazon.com/federation", "https://console.aws.amazon.com"},
+	"aws-cn":     {"https://signin.amazonaws.cn/federation", "https://console.amazonaws.cn"},
+	"aws-us-gov": {"https://signin.amazonaws-us-gov.com/federation", "https://console.amazonaws-us-gov.com"},
+}
+
+// federationClient is HTTP client for federation endpoint
+var federationClient = &http.Client{Timeout: 30 * time.Second}
+
+// signinTokenOutput is the response of getSigninToken
+type signinTokenOutput struct {
+	SigninToken string `json:"SigninToken"`
+}
+
+// GetConsoleEndpoints returns the federation endpoint and console URL in the partition of role
+func GetConsoleEndpoints(roleArn string) (string, string) {
+	partition := constants.DefaultPartition
+	if parsed, err := arn.Parse(roleArn); err == nil {
+		partition = parsed.Partition
+	}
+
+	endpoints, ok := partitionConsoles[partition]
+	if !ok {
+		endpoints = partitionConsoles[constants.DefaultPartition]
+	}
+
+	return endpoints[0], endpoints[1]
+}
+
+// GetSigninToken gets sign-in token of AWS console from federation endpoint
+func GetSigninToken(endpoint string, creds *sts.Credentials) (string, error) {
+	session, err := json.Marshal(map[string]string{
+		"sessionId":    *creds.AccessKeyId,
+		"sessionKey":   *creds.SecretAccessKey,
+		"sessionToken": *creds.SessionToken,
+	})
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	query := url.Values{}
+	query.Set("Action", "getSigninToken")
+	query.Set("Session", string(session))
+
+	resp, err := federationClient.Get(fmt.Sprintf("%s?%s", endpoint, query.Encode()))
+	if err != nil {
+		return constants.EmptyString, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return constants.EmptyString, fmt.Errorf("failed to get sign-in token from %s: %s", endpoint, resp.Status)
+	}
+
+	var output signinTokenOutput
+	if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+		return constants.EmptyString, err
+	}
+
+	if len(output.SigninToken) == 0 {
+		return constants.EmptyString, fmt.Errorf("no sign-in token is returned from %s", endpoint)
+	}
+
+	return output.SigninToken, nil
+}
+
+// BuildConsoleDestination returns console URL of service in the region
+func BuildConsoleDestination(console, service, region string) string {
+	if len(service) == 0 {
+		service = "console"
+	}
+
+	return fmt.Sprintf("%s/%s/home?region=%s", console, service, url.QueryEscape(region))
+}
+
+// BuildConsoleLoginURL returns URL which signs in to the console with the token
+func BuildConsoleLoginURL(endpoint, token, destination string) string {
+	query := url.Values{}
+	query.Set("Action", "login")
+	query.Set("Issuer", constants.ServiceName)
+	query.Set("Destination", destination)
+	query.Set("SigninToken", token)
+
+	return fmt.Sprintf("%s?%s", endpoint, query.Encode())
+}
@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+func TestGetSigninToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/federation" || r.URL.Query().Get("Action") != "getSigninToken" {
+			http.Error(w, "wrong action", http.StatusBadRequest)
+			return
+		}
+
+		var session map[string]string
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("Session")), &session); err != nil || session["sessionId"] != "ASIAEXAMPLE" {
+			http.Error(w, "wrong session", http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(`{"SigninToken":"signin-token"}`))
+	}))
+	defer server.Close()
+
+	creds := &sts.Credentials{
+		AccessKeyId:     aws.String("ASIAEXAMPLE"),
+		SecretAccessKey: aws.String("secret"),
+		SessionToken:    aws.String("token"),
+	}
+
+	token, err := GetSigninToken(server.URL+"/federation", creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token != "signin-token" {
+		t.Errorf("expected signin-token, output %s", token)
+	}
+
+	if _, err := GetSigninToken(server.URL+"/error", creds); err == nil {
+		t.Errorf("error response should return error")
+	}
+}
+
+func TestBuildConsoleLoginURL(t *testing.T) {
+	endpoint, console := GetConsoleEndpoints("arn:aws-cn:iam::123456789012:role/admin")
+	if endpoint != "https://signin.amazonaws.cn/federation" || console != "https://console.amazonaws.cn" {
+		t.Errorf("wrong endpoints of aws-cn: %s, %s", endpoint, console)
+	}
+
+	loginURL := BuildConsoleLoginURL("https://signin.aws.amazon.com/federation", "signin-token",
+		BuildConsoleDestination("https://console.aws.amazon.com", "ec2", "us-east-1"))
+
+	parsed, err := url.Parse(loginURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := parsed.Query()
+	if query.Get("Action") != "login" || query.Get("SigninToken") != "signin-token" {
+		t.Errorf("wrong login URL: %s", loginURL)
+	}
+
+	if destination := query.Get("Destination"); destination != "https://console.aws.amazon.com/ec2/home?region=us-east-1" {
+		t.Errorf("wrong destination: %s", destination)
+	}
+}
@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+// partitionRegions is the default region of each partition
+var partitionRegions = map[string]string{
+	"aws":        constants.DefaultRegion,
+	"aws-cn":     "cn-north-1",
+	"aws-us-gov": "us-gov-west-1",
+}
+
+// NewMFAClient creates IAM and STS clients for MFA authentication in the default region of partition
+// IAM and STS of aws-cn and aws-us-gov cannot be called in the region of aws partition.
+func NewMFAClient(sess client.ConfigProvider, cfg *schema.Config) Client {
+	region := GetPartitionRegion(cfg)
+	return Client{
+		IAMClient: iam.New(sess, &aws.Config{Region: aws.String(region)}),
+		STSClient: GetSTSClientFn(sess, region, nil),
+	}
+}
+
+// GetPartitionRegion returns default region of partition in configuration, which is also found from mfa_serial
+func GetPartitionRegion(cfg *schema.Config) string {
+	if region, ok := partitionRegions[cfg.Partition]; ok {
+		return region
+	}
+
+	return GetSTSRegion(cfg.MFASerial)
+}
+
+// GetMFASerialNumber returns MFA serial number of configuration, or discovers MFA device of user if it is not set
+func (c Client) GetMFASerialNumber(cfg *schema.Config) (string, error) {
+	if len(cfg.MFASerial) > 0 {
+		return cfg.MFASerial, nil
+	}
+
+	if len(cfg.AccountID) > 0 {
+		return BuildMFASerialNumber(cfg)
+	}
+
+	devices, err := c.ListMFADevices(cfg.Name)
+	if err != nil {
+		return constants.EmptyString, fmt.Errorf("failed to discover MFA device, please set `mfa_serial` in configuration: %s", err.Error())
+	}
+
+	if len(devices) == 0 {
+		return constants.EmptyString, fmt.Errorf("no MFA device is registered for %s", cfg.Name)
+	}
+
+	if len(devices) > 1 {
+		logrus.Warnf("%d MFA devices are registered, the first one will be used: %s", len(devices), *devices[0].SerialNumber)
+	}
+
+	return *devices[0].SerialNumber, nil
+}
+
+// BuildMFASerialNumber makes MFA serial number with partition, account ID and device name template
+func BuildMFASerialNumber(cfg *schema.Config) (string, error) {
+	partition := cfg.Partition
+	if len(partition) == 0 {
+		partition = constants.DefaultPartition
+	}
+
+	if _, ok := partitionRegions[partition]; !ok {
+		return constants.EmptyString, fmt.Errorf("partition is not supported: %s", partition)
+	}
+
+	deviceName := cfg.MFADeviceName
+	if len(deviceName) == 0 {
+		deviceName = constants.DefaultMFADeviceName
+	}
+
+	t, err := template.New("mfa device name").Parse(deviceName)
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, struct {
+		Name string
+		User string
+	}{
+		Name: cfg.Name,
+		User: strings.Split(cfg.Name, "@")[0],
+	}); err != nil {
+		return constants.EmptyString, err
+	}
+
+	return fmt.Sprintf("arn:%s:iam::%s:mfa/%s", partition, cfg.AccountID, b.String()), nil
+}
+
+// ListMFADevices lists MFA devices of user
+func (c Client) ListMFADevices(name string) ([]*iam.MFADevice, error) {
+	input := &iam.ListMFADevicesInput{
+		UserName: aws.String(name),
+	}
+
+	result, err := c.IAMClient.ListMFADevices(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.MFADevices, nil
+}
+
+// GetSTSRegion returns region for STS API in the partition of resource
+func GetSTSRegion(resourceArn string) string {
+	parsed, err := arn.Parse(resourceArn)
+	if err != nil {
+		return constants.DefaultRegion
+	}
+
+	if region, ok := partitionRegions[parsed.Partition]; ok {
+		return region
+	}
+
+	return constants.DefaultRegion
+}
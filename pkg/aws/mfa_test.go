@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestBuildMFASerialNumber(t *testing.T) {
+	testData := []struct {
+		input    schema.Config
+		expected string
+		isError  bool
+	}{
+		{
+			input:    schema.Config{Name: "gslee@gmail.com", AccountID: "123456789012"},
+			expected: "arn:aws:iam::123456789012:mfa/gslee@gmail.com",
+		},
+		{
+			input:    schema.Config{Name: "gslee@gmail.com", AccountID: "123456789012", MFADeviceName: "{{ .User }}-mfa", Partition: "aws-cn"},
+			expected: "arn:aws-cn:iam::123456789012:mfa/gslee-mfa",
+		},
+		{
+			input:   schema.Config{Name: "gslee@gmail.com", AccountID: "123456789012", Partition: "aws-unknown"},
+			isError: true,
+		},
+	}
+
+	for _, td := range testData {
+		output, err := BuildMFASerialNumber(&td.input)
+		if (err != nil) != td.isError {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if output != td.expected {
+			t.Errorf("expected: %s, output: %s", td.expected, output)
+		}
+	}
+}
+
+func TestGetSTSRegion(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+	}{
+		{input: "arn:aws:iam::123456789012:role/admin", expected: "ap-northeast-2"},
+		{input: "arn:aws-cn:iam::123456789012:role/admin", expected: "cn-north-1"},
+		{input: "arn:aws-us-gov:iam::123456789012:role/admin", expected: "us-gov-west-1"},
+	}
+
+	for _, td := range testData {
+		if output := GetSTSRegion(td.input); output != td.expected {
+			t.Errorf("expected: %s, output: %s", td.expected, output)
+		}
+	}
+}
+
+func TestGetPartitionRegion(t *testing.T) {
+	testData := []struct {
+		input    schema.Config
+		expected string
+	}{
+		{input: schema.Config{}, expected: "ap-northeast-2"},
+		{input: schema.Config{Partition: "aws-cn"}, expected: "cn-north-1"},
+		{input: schema.Config{MFASerial: "arn:aws-us-gov:iam::123456789012:mfa/gslee"}, expected: "us-gov-west-1"},
+	}
+
+	for _, td := range testData {
+		if output := GetPartitionRegion(&td.input); output != td.expected {
+			t.Errorf("expected: %s, output: %s", td.expected, output)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -50,5 +52,9 @@ func (c Client) GetLoadtestRDSStatus(dbClusterID string) (*string, error) {
 		return nil, err
 	}
 
+	if len(result.DBClusters) == 0 {
+		return nil, fmt.Errorf("db cluster does not exist: %s", dbClusterID)
+	}
+
 	return result.DBClusters[0].Status, nil
 }
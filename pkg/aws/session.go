@@ -1,14 +1,10 @@
 package aws
 
 import (
-	"fmt"
-
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
-
-	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/aws/aws-sdk-go/service/sts"
 )
 
 // GetAwsSession creates new session for AWS
@@ -27,35 +23,7 @@ func GetAwsSessionWithConfig(region string) (*session.Session, error) {
 	})
 }
 
-// GenerateCreds creates new credentials with MFA
-func GenerateCreds(assumeRole, name string) *credentials.Credentials {
-	awsSession := GetAwsSession()
-	roleSessionName := getRoleSessionName(name)
-	mfaSerialNumber := getMFASerialNumber(roleSessionName)
-
-	var creds *credentials.Credentials
-
-	mfaNumber, err := stscreds.StdinTokenProvider()
-	if err != nil {
-		return nil
-	}
-
-	if len(assumeRole) != 0 {
-		creds = stscreds.NewCredentials(awsSession, assumeRole, func(p *stscreds.AssumeRoleProvider) {
-			p.SerialNumber = aws.String(mfaSerialNumber)
-			p.TokenCode = aws.String(mfaNumber)
-			p.RoleSessionName = roleSessionName
-		})
-	}
-	return creds
-}
-
-// GetRoleSessionName create username which will be used to session name
-func getRoleSessionName(name string) string {
-	return name
-}
-
-// GetMFASerialNumber generates MFA serial number
-func getMFASerialNumber(email string) string {
-	return fmt.Sprintf("%s/%s", constants.BaseSerialNumber, email)
+// NewStaticCredentials creates credentials from STS credentials
+func NewStaticCredentials(creds *sts.Credentials) *credentials.Credentials {
+	return credentials.NewStaticCredentials(*creds.AccessKeyId, *creds.SecretAccessKey, *creds.SessionToken)
 }
@@ -32,38 +32,49 @@ func (c Client) CheckWhoIam(out io.Writer) error {
 	return nil
 }
 
-// CheckMFAToken checks MFA authentication for specific functions
-func (c Client) CheckMFAToken(name string) error {
-	roleSessionName := getRoleSessionName(name)
-	mfaSerialNumber := getMFASerialNumber(roleSessionName)
+// GetCallerIdentity returns identity of current credentials
+func (c Client) GetCallerIdentity() (*sts.GetCallerIdentityOutput, error) {
+	return c.STSClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+}
+
+// CheckMFAToken checks MFA authentication and returns MFA session credentials
+func (c Client) CheckMFAToken(mfaSerialNumber string, duration int) (*sts.Credentials, error) {
 	mfaToken, err := AskMFAToken()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return c.GetSessionToken(mfaSerialNumber, mfaToken)
+	return c.GetSessionToken(mfaSerialNumber, mfaToken, duration)
 }
 
-// getSessionToken retrieves session token with MFA authentication
-func (c Client) GetSessionToken(mfaSerialNumber, mfaToken string) error {
+// GetSessionToken retrieves session token with MFA authentication
+func (c Client) GetSessionToken(mfaSerialNumber, mfaToken string, duration int) (*sts.Credentials, error) {
 	input := &sts.GetSessionTokenInput{
-		SerialNumber: aws.String(mfaSerialNumber),
-		TokenCode:    aws.String(mfaToken),
+		SerialNumber:    aws.String(mfaSerialNumber),
+		TokenCode:       aws.String(mfaToken),
+		DurationSeconds: aws.Int64(int64(duration)),
 	}
 
-	_, err := c.STSClient.GetSessionToken(input)
+	result, err := c.STSClient.GetSessionToken(input)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return result.Credentials, nil
 }
 
 // AskMFAToken gets MFA token from command line interface
+// It reads from the terminal if possible, because stdin and stdout could be used by other process.
 func AskMFAToken() (string, error) {
+	var in io.Reader = os.Stdin
+	if tty, err := os.Open("/dev/tty"); err == nil {
+		defer tty.Close()
+		in = tty
+	}
+
 	var v string
 	fmt.Fprintf(os.Stderr, "MFA token code: ")
-	_, err := fmt.Scanln(&v)
+	_, err := fmt.Fscanln(in, &v)
 
 	return v, err
 }
@@ -3,18 +3,41 @@ package aws
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/aws/aws-sdk-go/service/wafregional"
+	"github.com/sirupsen/logrus"
 
 	"github.com/DevopsArtFactory/act/pkg/constants"
 	"github.com/DevopsArtFactory/act/pkg/schema"
 )
 
+// ClassicWafAPI is the API shared by WAF Classic and WAF Regional
+type ClassicWafAPI interface {
+	ListWebACLs(*waf.ListWebACLsInput) (*waf.ListWebACLsOutput, error)
+	GetWebACL(*waf.GetWebACLInput) (*waf.GetWebACLOutput, error)
+	GetRule(*waf.GetRuleInput) (*waf.GetRuleOutput, error)
+	GetRateBasedRule(*waf.GetRateBasedRuleInput) (*waf.GetRateBasedRuleOutput, error)
+	GetRuleGroup(*waf.GetRuleGroupInput) (*waf.GetRuleGroupOutput, error)
+	GetByteMatchSet(*waf.GetByteMatchSetInput) (*waf.GetByteMatchSetOutput, error)
+	GetGeoMatchSet(*waf.GetGeoMatchSetInput) (*waf.GetGeoMatchSetOutput, error)
+	GetSqlInjectionMatchSet(*waf.GetSqlInjectionMatchSetInput) (*waf.GetSqlInjectionMatchSetOutput, error)
+	GetXssMatchSet(*waf.GetXssMatchSetInput) (*waf.GetXssMatchSetOutput, error)
+	GetSizeConstraintSet(*waf.GetSizeConstraintSetInput) (*waf.GetSizeConstraintSetOutput, error)
+	GetRegexMatchSet(*waf.GetRegexMatchSetInput) (*waf.GetRegexMatchSetOutput, error)
+	GetRegexPatternSet(*waf.GetRegexPatternSetInput) (*waf.GetRegexPatternSetOutput, error)
+	GetIPSet(*waf.GetIPSetInput) (*waf.GetIPSetOutput, error)
+	ListIPSets(*waf.ListIPSetsInput) (*waf.ListIPSetsOutput, error)
+	GetChangeToken(*waf.GetChangeTokenInput) (*waf.GetChangeTokenOutput, error)
+	UpdateIPSet(*waf.UpdateIPSetInput) (*waf.UpdateIPSetOutput, error)
+}
+
 func GetWafClientFn(sess client.ConfigProvider, region string, creds *credentials.Credentials) *waf.WAF {
 	if creds == nil {
 		return waf.New(sess, &aws.Config{Region: aws.String(region)})
@@ -22,117 +45,265 @@ func GetWafClientFn(sess client.ConfigProvider, region string, creds *credential
 	return waf.New(sess, &aws.Config{Region: aws.String(region), Credentials: creds})
 }
 
-// SelectAcl selects target acl name from all acl list
-func (c Client) SelectACL() (string, error) {
+func GetWafRegionalClientFn(sess client.ConfigProvider, region string, creds *credentials.Credentials) *wafregional.WAFRegional {
+	if creds == nil {
+		return wafregional.New(sess, &aws.Config{Region: aws.String(region)})
+	}
+	return wafregional.New(sess, &aws.Config{Region: aws.String(region), Credentials: creds})
+}
+
+// classicWafClient returns client of WAF Classic, ACLs attached to regional resources are served by WAF Regional
+func (c Client) classicWafClient(scope string) ClassicWafAPI {
+	if scope == constants.RegionalScope {
+		return c.WafRegionalClient
+	}
+	return c.WafClient
+}
+
+// SelectAcl selects target acl from all acl list
+func (c Client) SelectACL(version, scope string) (schema.WebACLSummary, error) {
 	var target string
 
-	ACLs, err := c.GetAllWebACLs()
+	ACLs, err := c.ListWebACLs(version, scope)
 	if err != nil {
-		return constants.EmptyString, err
+		return schema.WebACLSummary{}, err
+	}
+
+	if len(ACLs) == 0 {
+		return schema.WebACLSummary{}, errors.New("no web ACL exists")
 	}
 
 	var options []string
+	candidates := map[string]schema.WebACLSummary{}
 	for _, acl := range ACLs {
-		options = append(options, fmt.Sprintf("%s / %s", *acl.Name, *acl.WebACLId))
+		option := fmt.Sprintf("%s / %s [%s]", acl.Name, acl.ID, GetWebACLLabel(acl))
+		options = append(options, option)
+		candidates[option] = acl
 	}
 
 	prompt := &survey.Select{
 		Message: "Choose the ACL: ",
 		Options: options,
 	}
-	survey.AskOne(prompt, &target)
+	if err := survey.AskOne(prompt, &target); err != nil {
+		return schema.WebACLSummary{}, err
+	}
 
 	if len(target) == 0 {
-		return constants.EmptyString, errors.New("you canceled ACL selection")
+		return schema.WebACLSummary{}, errors.New("you canceled ACL selection")
 	}
 
-	return ParseWebACLID(target), nil
+	return candidates[target], nil
 }
 
-// GetAllWebACLs retrieves all ACLs in AWS WAF
-func (c Client) GetAllWebACLs() ([]*waf.WebACLSummary, error) {
-	input := &waf.ListWebACLsInput{
-		Limit: aws.Int64(100),
+// ListWebACLs retrieves web ACLs of WAF Classic and WAFv2 with version and scope
+// API which fails is skipped with warning, e.g. access is denied or it is not supported in the region.
+// Error is returned only if all APIs fail.
+func (c Client) ListWebACLs(version, scope string) ([]schema.WebACLSummary, error) {
+	var ACLs []schema.WebACLSummary
+	var errs []error
+	sources := 0
+	collect := func(summary schema.WebACLSummary, list func(string) ([]schema.WebACLSummary, error)) {
+		sources++
+		found, err := list(summary.Scope)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list web ACLs of %s: %w", GetWebACLLabel(summary), err))
+			return
+		}
+		ACLs = append(ACLs, found...)
+	}
+
+	for _, s := range constants.WafScopes {
+		if len(scope) > 0 && s != scope {
+			continue
+		}
+
+		if version != constants.WafV2Version {
+			collect(schema.WebACLSummary{Version: constants.ClassicWafVersion, Scope: s}, c.listClassicWebACLs)
+		}
+
+		if version != constants.ClassicWafVersion {
+			collect(schema.WebACLSummary{Version: constants.WafV2Version, Scope: s}, c.GetAllWebACLsV2)
+		}
 	}
 
-	result, err := c.WafClient.ListWebACLs(input)
+	if len(errs) > 0 && len(errs) == sources {
+		return nil, errs[0]
+	}
+
+	for _, err := range errs {
+		logrus.Warnf("%s, skipped", err.Error())
+	}
+
+	return ACLs, nil
+}
+
+// listClassicWebACLs retrieves web ACLs of WAF Classic in scope
+func (c Client) listClassicWebACLs(scope string) ([]schema.WebACLSummary, error) {
+	classic, err := c.GetAllWebACLs(scope)
 	if err != nil {
 		return nil, err
 	}
 
-	return result.WebACLs, nil
+	var ACLs []schema.WebACLSummary
+	for _, acl := range classic {
+		ACLs = append(ACLs, schema.WebACLSummary{
+			ID:      *acl.WebACLId,
+			Name:    *acl.Name,
+			Version: constants.ClassicWafVersion,
+			Scope:   scope,
+		})
+	}
+
+	return ACLs, nil
+}
+
+// FindWebACL finds web ACL with ID or name
+func (c Client) FindWebACL(target, version, scope string) (schema.WebACLSummary, error) {
+	// ID of WAF Classic can be used without searching if scope is given
+	if version == constants.ClassicWafVersion && len(scope) > 0 {
+		return schema.WebACLSummary{ID: target, Version: version, Scope: scope}, nil
+	}
+
+	ACLs, err := c.ListWebACLs(version, scope)
+	if err != nil {
+		return schema.WebACLSummary{}, err
+	}
+
+	var found []schema.WebACLSummary
+	for _, acl := range ACLs {
+		if acl.ID == target || acl.Name == target {
+			found = append(found, acl)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return schema.WebACLSummary{}, fmt.Errorf("web ACL does not exist: %s", target)
+	case 1:
+		return found[0], nil
+	}
+
+	return schema.WebACLSummary{}, fmt.Errorf("%d web ACLs are named %s, please use --waf-version or --scope", len(found), target)
+}
+
+// GetAllWebACLs retrieves all ACLs in AWS WAF Classic of scope
+func (c Client) GetAllWebACLs(scope string) ([]*waf.WebACLSummary, error) {
+	input := &waf.ListWebACLsInput{
+		Limit: aws.Int64(constants.MaxWafListLimit),
+	}
+
+	var ACLs []*waf.WebACLSummary
+	for {
+		result, err := c.classicWafClient(scope).ListWebACLs(input)
+		if err != nil {
+			return nil, err
+		}
+		ACLs = append(ACLs, result.WebACLs...)
+
+		if result.NextMarker == nil || len(result.WebACLs) == 0 {
+			break
+		}
+		input.NextMarker = result.NextMarker
+	}
+
+	return ACLs, nil
 }
 
-// DescribeWebACL describes web acl
-func (c Client) DescribeWebACL(target string) (*schema.WebACL, error) {
-	var ret schema.WebACL
-	info, err := c.GetWebACLInfo(target)
+// DescribeWebACL describes web acl with the API of its version
+// Requests are sent within the limiter, which can be shared to describe web ACLs at the same time.
+func (c Client) DescribeWebACL(summary schema.WebACLSummary, limiter WafLimiter) (*schema.WebACL, error) {
+	if summary.Version == constants.WafV2Version {
+		return c.DescribeWebACLV2(summary, limiter)
+	}
+
+	return c.describeClassicWebACL(summary, limiter)
+}
+
+// describeClassicWebACL describes web acl of WAF Classic, rules are retrieved in parallel
+func (c Client) describeClassicWebACL(summary schema.WebACLSummary, limiter WafLimiter) (*schema.WebACL, error) {
+	var info *waf.WebACL
+	err := limiter.do(func() error {
+		var err error
+		info, err = c.GetWebACLInfo(summary.ID, summary.Scope)
+		return err
+	})
 	if err != nil {
-		return nil, nil
-	}
-
-	// basic information
-	ret.ID = *info.WebACLId
-	ret.Name = *info.Name
-
-	if len(info.Rules) > 0 {
-		rules := []schema.ACLRule{}
-		for _, rule := range info.Rules {
-			rules = append(rules, schema.ACLRule{
-				Type:       *rule.Type,
-				ActionType: *rule.Action.Type,
-				Priority:   *rule.Priority,
-				RuleID:     *rule.RuleId,
-			})
+		return nil, fmt.Errorf("failed to get web ACL %s: %w", summary.ID, err)
+	}
+
+	ret := schema.WebACL{
+		ID:            *info.WebACLId,
+		Name:          *info.Name,
+		Version:       constants.ClassicWafVersion,
+		Scope:         summary.Scope,
+		DefaultAction: aws.StringValue(info.DefaultAction.Type),
+		Rules:         make([]schema.ACLRule, len(info.Rules)),
+	}
+
+	reader := newWafReader(limiter)
+	err = reader.run(len(info.Rules), func(i int) error {
+		rule := info.Rules[i]
+		aclRule := schema.ACLRule{
+			Type:       aws.StringValue(rule.Type),
+			ActionType: getClassicRuleAction(rule),
+			Priority:   aws.Int64Value(rule.Priority),
+			RuleID:     *rule.RuleId,
 		}
-		ret.Rules = rules
-	}
-
-	if len(ret.Rules) > 0 {
-		for i := range ret.Rules {
-			ruleInfo, err := c.DescribeRule(ret.Rules[i].RuleID)
-			if err != nil {
-				return nil, err
-			}
-
-			var dataSet []schema.IPDataSet
-			if len(ruleInfo.Predicates) > 0 {
-				for _, p := range ruleInfo.Predicates {
-					data, err := c.GetIPSet(*p.DataId)
-					if err != nil {
-						return nil, err
-					}
-
-					tempIPSet := schema.IPDataSet{
-						ID: *p.DataId,
-					}
-
-					l := []string{}
-					if len(data.IPSetDescriptors) > 0 {
-						for _, descriptor := range data.IPSetDescriptors {
-							l = append(l, *descriptor.Value)
-						}
-					}
-
-					tempIPSet.IPList = l
-					dataSet = append(dataSet, tempIPSet)
-				}
-
-				ret.Rules[i].IPDataSet = dataSet
-			}
+
+		if err := c.describeClassicRule(reader, &aclRule, summary.Scope); err != nil {
+			return fmt.Errorf("failed to get rule %s: %w", aclRule.RuleID, err)
 		}
+
+		ret.Rules[i] = aclRule
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &ret, nil
 }
 
+// getClassicIPDataSet returns addresses of IP set in WAF Classic
+func (c Client) getClassicIPDataSet(dataID, scope string) (schema.IPDataSet, error) {
+	data, err := c.GetIPSet(dataID, scope)
+	if err != nil {
+		return schema.IPDataSet{}, err
+	}
+
+	ipSet := schema.IPDataSet{
+		ID:     dataID,
+		Name:   aws.StringValue(data.Name),
+		IPList: []string{},
+	}
+	for _, descriptor := range data.IPSetDescriptors {
+		ipSet.IPList = append(ipSet.IPList, *descriptor.Value)
+	}
+
+	return ipSet, nil
+}
+
+// getClassicRuleAction returns action of rule, override action is used for rule group
+func getClassicRuleAction(rule *waf.ActivatedRule) string {
+	if rule.Action != nil {
+		return aws.StringValue(rule.Action.Type)
+	}
+
+	if rule.OverrideAction != nil {
+		return aws.StringValue(rule.OverrideAction.Type)
+	}
+
+	return constants.EmptyString
+}
+
 // GetWebACLInfo retrieves web acl information
-func (c Client) GetWebACLInfo(target string) (*waf.WebACL, error) {
+func (c Client) GetWebACLInfo(target, scope string) (*waf.WebACL, error) {
 	input := &waf.GetWebACLInput{
 		WebACLId: aws.String(target),
 	}
 
-	result, err := c.WafClient.GetWebACL(input)
+	result, err := c.classicWafClient(scope).GetWebACL(input)
 	if err != nil {
 		return nil, err
 	}
@@ -141,12 +312,12 @@ func (c Client) GetWebACLInfo(target string) (*waf.WebACL, error) {
 }
 
 // DescribeRule describes web ACL rule
-func (c Client) DescribeRule(ruleID string) (*waf.Rule, error) {
+func (c Client) DescribeRule(ruleID, scope string) (*waf.Rule, error) {
 	input := &waf.GetRuleInput{
 		RuleId: aws.String(ruleID),
 	}
 
-	result, err := c.WafClient.GetRule(input)
+	result, err := c.classicWafClient(scope).GetRule(input)
 	if err != nil {
 		return nil, err
 	}
@@ -155,12 +326,12 @@ func (c Client) DescribeRule(ruleID string) (*waf.Rule, error) {
 }
 
 // GetIPSet retrieves information about IP Set
-func (c Client) GetIPSet(dataID string) (*waf.IPSet, error) {
+func (c Client) GetIPSet(dataID, scope string) (*waf.IPSet, error) {
 	input := &waf.GetIPSetInput{
 		IPSetId: aws.String(dataID),
 	}
 
-	result, err := c.WafClient.GetIPSet(input)
+	result, err := c.classicWafClient(scope).GetIPSet(input)
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +339,22 @@ func (c Client) GetIPSet(dataID string) (*waf.IPSet, error) {
 	return result.IPSet, nil
 }
 
-// ParseWebACLID parses web ACL ID from option string
-func ParseWebACLID(str string) string {
-	return strings.TrimSpace(strings.Split(str, "/")[1])
+// GetWebACLLabel returns label of the API and scope which web ACL belongs to
+func GetWebACLLabel(acl schema.WebACLSummary) string {
+	switch {
+	case acl.Version == constants.WafV2Version:
+		return fmt.Sprintf("WAFv2 %s", acl.Scope)
+	case acl.Scope == constants.RegionalScope:
+		return "WAF Regional"
+	}
+	return "WAF Classic CLOUDFRONT"
+}
+
+// IsThrottlingError checks if error is caused by API rate limit
+func IsThrottlingError(err error) bool {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		return request.IsErrorThrottle(aerr)
+	}
+	return false
 }
@@ -0,0 +1,356 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+// SelectIPSet selects target IP set from all IP set list
+func (c Client) SelectIPSet(version, scope string) (schema.IPSetSummary, error) {
+	var target string
+
+	ipSets, err := c.ListIPSets(version, scope)
+	if err != nil {
+		return schema.IPSetSummary{}, err
+	}
+
+	if len(ipSets) == 0 {
+		return schema.IPSetSummary{}, errors.New("no IP set exists")
+	}
+
+	var options []string
+	candidates := map[string]schema.IPSetSummary{}
+	for _, ipSet := range ipSets {
+		option := fmt.Sprintf("%s / %s [%s]", ipSet.Name, ipSet.ID, GetIPSetLabel(ipSet))
+		options = append(options, option)
+		candidates[option] = ipSet
+	}
+
+	prompt := &survey.Select{
+		Message: "Choose the IP set: ",
+		Options: options,
+	}
+	if err := survey.AskOne(prompt, &target); err != nil {
+		return schema.IPSetSummary{}, err
+	}
+
+	if len(target) == 0 {
+		return schema.IPSetSummary{}, errors.New("you canceled IP set selection")
+	}
+
+	return candidates[target], nil
+}
+
+// FindIPSet finds IP set with ID or name
+func (c Client) FindIPSet(target, version, scope string) (schema.IPSetSummary, error) {
+	ipSets, err := c.ListIPSets(version, scope)
+	if err != nil {
+		return schema.IPSetSummary{}, err
+	}
+
+	var found []schema.IPSetSummary
+	for _, ipSet := range ipSets {
+		if ipSet.ID == target || ipSet.Name == target {
+			found = append(found, ipSet)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return schema.IPSetSummary{}, fmt.Errorf("IP set does not exist: %s", target)
+	case 1:
+		return found[0], nil
+	}
+
+	return schema.IPSetSummary{}, fmt.Errorf("%d IP sets are named %s, please use --waf-version or --scope", len(found), target)
+}
+
+// ListIPSets retrieves IP sets of WAF Classic and WAFv2 with version and scope
+// API which fails is skipped with warning like ListWebACLs, and error is returned only if all APIs fail.
+func (c Client) ListIPSets(version, scope string) ([]schema.IPSetSummary, error) {
+	var ipSets []schema.IPSetSummary
+	var errs []error
+	sources := 0
+	collect := func(summary schema.IPSetSummary, list func(string) ([]schema.IPSetSummary, error)) {
+		sources++
+		found, err := list(summary.Scope)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list IP sets of %s: %w", GetIPSetLabel(summary), err))
+			return
+		}
+		ipSets = append(ipSets, found...)
+	}
+
+	for _, s := range constants.WafScopes {
+		if len(scope) > 0 && s != scope {
+			continue
+		}
+
+		if version != constants.WafV2Version {
+			collect(schema.IPSetSummary{Version: constants.ClassicWafVersion, Scope: s}, c.listClassicIPSets)
+		}
+
+		if version != constants.ClassicWafVersion {
+			collect(schema.IPSetSummary{Version: constants.WafV2Version, Scope: s}, c.listIPSetsV2)
+		}
+	}
+
+	if len(errs) > 0 && len(errs) == sources {
+		return nil, errs[0]
+	}
+
+	for _, err := range errs {
+		logrus.Warnf("%s, skipped", err.Error())
+	}
+
+	return ipSets, nil
+}
+
+// listClassicIPSets retrieves IP sets of WAF Classic in scope
+func (c Client) listClassicIPSets(scope string) ([]schema.IPSetSummary, error) {
+	input := &waf.ListIPSetsInput{
+		Limit: aws.Int64(constants.MaxWafListLimit),
+	}
+
+	var ipSets []schema.IPSetSummary
+	for {
+		result, err := c.classicWafClient(scope).ListIPSets(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ipSet := range result.IPSets {
+			ipSets = append(ipSets, schema.IPSetSummary{
+				ID:      *ipSet.IPSetId,
+				Name:    *ipSet.Name,
+				Version: constants.ClassicWafVersion,
+				Scope:   scope,
+			})
+		}
+
+		if result.NextMarker == nil || len(result.IPSets) == 0 {
+			break
+		}
+		input.NextMarker = result.NextMarker
+	}
+
+	return ipSets, nil
+}
+
+// listIPSetsV2 retrieves IP sets of WAFv2 in scope
+func (c Client) listIPSetsV2(scope string) ([]schema.IPSetSummary, error) {
+	input := &wafv2.ListIPSetsInput{
+		Limit: aws.Int64(constants.MaxWafListLimit),
+		Scope: aws.String(scope),
+	}
+
+	var ipSets []schema.IPSetSummary
+	for {
+		result, err := c.wafV2Client(scope).ListIPSets(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ipSet := range result.IPSets {
+			ipSets = append(ipSets, schema.IPSetSummary{
+				ID:      *ipSet.Id,
+				Name:    *ipSet.Name,
+				ARN:     *ipSet.ARN,
+				Version: constants.WafV2Version,
+				Scope:   scope,
+			})
+		}
+
+		if result.NextMarker == nil || len(result.IPSets) == 0 {
+			break
+		}
+		input.NextMarker = result.NextMarker
+	}
+
+	return ipSets, nil
+}
+
+// GetIPSetAddresses returns addresses in IP set
+func (c Client) GetIPSetAddresses(ipSet schema.IPSetSummary) ([]string, error) {
+	if ipSet.Version == constants.WafV2Version {
+		data, err := c.GetIPSetV2(ipSet.ARN)
+		if err != nil {
+			return nil, err
+		}
+		return data.IPList, nil
+	}
+
+	data, err := c.GetIPSet(ipSet.ID, ipSet.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+	for _, descriptor := range data.IPSetDescriptors {
+		addresses = append(addresses, *descriptor.Value)
+	}
+
+	return addresses, nil
+}
+
+// UpdateIPSetAddresses adds and removes addresses in IP set
+func (c Client) UpdateIPSetAddresses(ipSet schema.IPSetSummary, add, remove []string) error {
+	if ipSet.Version == constants.WafV2Version {
+		return c.updateIPSetV2(ipSet, add, remove)
+	}
+
+	return c.updateClassicIPSet(ipSet, add, remove)
+}
+
+// updateClassicIPSet updates IP set of WAF Classic in batches, because the number of updates in a request is limited
+func (c Client) updateClassicIPSet(ipSet schema.IPSetSummary, add, remove []string) error {
+	var updates []*waf.IPSetUpdate
+	for _, address := range remove {
+		updates = append(updates, buildIPSetUpdate(waf.ChangeActionDelete, address))
+	}
+	for _, address := range add {
+		updates = append(updates, buildIPSetUpdate(waf.ChangeActionInsert, address))
+	}
+
+	for start := 0; start < len(updates); start += constants.MaxClassicIPSetUpdates {
+		end := start + constants.MaxClassicIPSetUpdates
+		if end > len(updates) {
+			end = len(updates)
+		}
+
+		if err := c.updateClassicIPSetBatch(ipSet, updates[start:end]); err != nil {
+			return err
+		}
+		logrus.Debugf("%d updates are applied to IP set %s", end-start, ipSet.ID)
+	}
+
+	return nil
+}
+
+// updateClassicIPSetBatch updates IP set of WAF Classic with a new change token, which is retried if the token is stale
+func (c Client) updateClassicIPSetBatch(ipSet schema.IPSetSummary, updates []*waf.IPSetUpdate) error {
+	client := c.classicWafClient(ipSet.Scope)
+	for retry := 0; ; retry++ {
+		token, err := client.GetChangeToken(&waf.GetChangeTokenInput{})
+		if err != nil {
+			return err
+		}
+
+		_, err = client.UpdateIPSet(&waf.UpdateIPSetInput{
+			ChangeToken: token.ChangeToken,
+			IPSetId:     aws.String(ipSet.ID),
+			Updates:     updates,
+		})
+		if err == nil {
+			return nil
+		}
+
+		if !isWafErrorCode(err, waf.ErrCodeStaleDataException) || retry >= constants.MaxWafUpdateRetry {
+			return err
+		}
+		logrus.Warnf("change token is stale, retrying to update IP set %s", ipSet.ID)
+	}
+}
+
+// updateIPSetV2 replaces addresses of WAFv2 IP set with the latest lock token, which is retried if the token is stale
+func (c Client) updateIPSetV2(ipSet schema.IPSetSummary, add, remove []string) error {
+	scope, name, id, err := ParseWafV2ARN(ipSet.ARN)
+	if err != nil {
+		return err
+	}
+
+	client := c.wafV2Client(scope)
+	for retry := 0; ; retry++ {
+		current, err := client.GetIPSet(&wafv2.GetIPSetInput{
+			Id:    aws.String(id),
+			Name:  aws.String(name),
+			Scope: aws.String(scope),
+		})
+		if err != nil {
+			return err
+		}
+
+		// addresses of WAFv2 IP set are replaced at once
+		addresses := ApplyAddressChanges(aws.StringValueSlice(current.IPSet.Addresses), add, remove)
+		if len(addresses) > constants.MaxIPSetAddresses {
+			return fmt.Errorf("IP set %s cannot have more than %d addresses: %d", ipSet.Name, constants.MaxIPSetAddresses, len(addresses))
+		}
+
+		_, err = client.UpdateIPSet(&wafv2.UpdateIPSetInput{
+			Addresses:   aws.StringSlice(addresses),
+			Description: current.IPSet.Description,
+			Id:          aws.String(id),
+			LockToken:   current.LockToken,
+			Name:        aws.String(name),
+			Scope:       aws.String(scope),
+		})
+		if err == nil {
+			return nil
+		}
+
+		if !isWafErrorCode(err, wafv2.ErrCodeWAFOptimisticLockException) || retry >= constants.MaxWafUpdateRetry {
+			return err
+		}
+		logrus.Warnf("lock token is stale, retrying to update IP set %s", ipSet.ID)
+	}
+}
+
+// ApplyAddressChanges returns addresses after removing and adding addresses
+func ApplyAddressChanges(addresses, add, remove []string) []string {
+	removed := map[string]bool{}
+	for _, address := range remove {
+		removed[address] = true
+	}
+
+	ret := []string{}
+	exists := map[string]bool{}
+	for _, address := range append(addresses, add...) {
+		if removed[address] || exists[address] {
+			continue
+		}
+		exists[address] = true
+		ret = append(ret, address)
+	}
+
+	return ret
+}
+
+// buildIPSetUpdate returns update of WAF Classic IP set
+func buildIPSetUpdate(action, address string) *waf.IPSetUpdate {
+	descriptorType := waf.IPSetDescriptorTypeIpv4
+	if strings.Contains(address, ":") {
+		descriptorType = waf.IPSetDescriptorTypeIpv6
+	}
+
+	return &waf.IPSetUpdate{
+		Action: aws.String(action),
+		IPSetDescriptor: &waf.IPSetDescriptor{
+			Type:  aws.String(descriptorType),
+			Value: aws.String(address),
+		},
+	}
+}
+
+// isWafErrorCode checks if error has the code
+func isWafErrorCode(err error, code string) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == code
+	}
+	return false
+}
+
+// GetIPSetLabel returns label of the API and scope which IP set belongs to
+func GetIPSetLabel(ipSet schema.IPSetSummary) string {
+	return GetWebACLLabel(schema.WebACLSummary{Version: ipSet.Version, Scope: ipSet.Scope})
+}
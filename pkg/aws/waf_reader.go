@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"sync"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+)
+
+// WafLimiter limits the number of WAF requests in flight, which is shared by all web ACLs described at the same time
+type WafLimiter chan struct{}
+
+// NewWafLimiter creates limiter which allows concurrency requests at the same time
+func NewWafLimiter(concurrency int) WafLimiter {
+	if concurrency <= 0 {
+		concurrency = constants.DefaultWafConcurrency
+	}
+
+	return make(WafLimiter, concurrency)
+}
+
+// do calls fn after a slot of limiter is acquired
+func (l WafLimiter) do(fn func() error) error {
+	l <- struct{}{}
+	defer func() { <-l }()
+
+	return fn()
+}
+
+// wafReader reads rules of web ACL with workers, and resources shared between rules are retrieved once
+type wafReader struct {
+	limiter WafLimiter
+	mutex   sync.Mutex
+	cache   map[string]*cachedResource
+}
+
+// cachedResource is the resource retrieved by wafReader
+type cachedResource struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+func newWafReader(limiter WafLimiter) *wafReader {
+	return &wafReader{
+		limiter: limiter,
+		cache:   map[string]*cachedResource{},
+	}
+}
+
+// get returns resource of key from cache, or retrieves it with fetch only once even if it is requested at the same time
+func (r *wafReader) get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	r.mutex.Lock()
+	resource, ok := r.cache[key]
+	if !ok {
+		resource = &cachedResource{}
+		r.cache[key] = resource
+	}
+	r.mutex.Unlock()
+
+	resource.once.Do(func() {
+		resource.value, resource.err = fetch()
+	})
+
+	return resource.value, resource.err
+}
+
+// run calls fn with index from 0 to count-1 in workers, and returns the first error
+// Each call holds a slot of the limiter, so requests are bounded across web ACLs.
+// Remaining jobs are skipped after an error occurs.
+func (r *wafReader) run(count int, fn func(int) error) error {
+	var firstErr error
+	var errMutex sync.Mutex
+	failed := func() bool {
+		errMutex.Lock()
+		defer errMutex.Unlock()
+		return firstErr != nil
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < cap(r.limiter) && i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				if failed() {
+					continue
+				}
+
+				if err := r.limiter.do(func() error { return fn(idx) }); err != nil {
+					errMutex.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMutex.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return firstErr
+}
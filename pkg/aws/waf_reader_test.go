@@ -0,0 +1,112 @@
+package aws
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWafReaderGet(t *testing.T) {
+	reader := newWafReader(NewWafLimiter(4))
+
+	var calls int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := reader.get("ipset-1", func() (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				return "office", nil
+			})
+			if err != nil || value.(string) != "office" {
+				t.Errorf("unexpected result: %v, %v", value, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestWafReaderRun(t *testing.T) {
+	testData := []struct {
+		count       int
+		concurrency int
+		failAt      int
+		err         bool
+	}{
+		{count: 0, concurrency: 3, failAt: -1},
+		{count: 25, concurrency: 3, failAt: -1},
+		{count: 25, concurrency: 0, failAt: -1},
+		{count: 25, concurrency: 3, failAt: 7, err: true},
+	}
+
+	for _, td := range testData {
+		reader := newWafReader(NewWafLimiter(td.concurrency))
+		done := make([]bool, td.count)
+		err := reader.run(td.count, func(i int) error {
+			if i == td.failAt {
+				return errors.New("throttled")
+			}
+			done[i] = true
+			return nil
+		})
+
+		if td.err {
+			if err == nil {
+				t.Errorf("expected error: %d jobs", td.count)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		for i := range done {
+			if !done[i] {
+				t.Errorf("job %d is not done", i)
+			}
+		}
+	}
+}
+
+func TestWafReaderSharesLimiter(t *testing.T) {
+	limiter := NewWafLimiter(3)
+
+	var inFlight, maxInFlight int32
+	job := func(int) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return nil
+	}
+
+	// web ACLs described at the same time share the limiter
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := newWafReader(limiter).run(20, job); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 3 {
+		t.Errorf("expected at most 3 requests in flight, output %d", maxInFlight)
+	}
+}
@@ -0,0 +1,216 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+// describeClassicRule fills name and conditions of WAF Classic rule
+// Only IPMatch predicates are retrieved as IP sets, and predicates of a rule are combined with AND.
+func (c Client) describeClassicRule(reader *wafReader, rule *schema.ACLRule, scope string) error {
+	client := c.classicWafClient(scope)
+
+	var predicates []*waf.Predicate
+	switch rule.Type {
+	case waf.WafRuleTypeGroup:
+		group, err := reader.get(constants.RuleGroupCondition+"/"+rule.RuleID, func() (interface{}, error) {
+			return client.GetRuleGroup(&waf.GetRuleGroupInput{RuleGroupId: aws.String(rule.RuleID)})
+		})
+		if err != nil {
+			return err
+		}
+
+		rule.Name = aws.StringValue(group.(*waf.GetRuleGroupOutput).RuleGroup.Name)
+		rule.Conditions = append(rule.Conditions, schema.RuleCondition{
+			Type:   constants.RuleGroupCondition,
+			DataID: rule.RuleID,
+			Name:   rule.Name,
+		})
+		return nil
+	case waf.WafRuleTypeRateBased:
+		result, err := client.GetRateBasedRule(&waf.GetRateBasedRuleInput{RuleId: aws.String(rule.RuleID)})
+		if err != nil {
+			return err
+		}
+
+		rule.Name = aws.StringValue(result.Rule.Name)
+		rule.RateKey = aws.StringValue(result.Rule.RateKey)
+		rule.RateLimit = aws.Int64Value(result.Rule.RateLimit)
+		predicates = result.Rule.MatchPredicates
+	default:
+		result, err := c.DescribeRule(rule.RuleID, scope)
+		if err != nil {
+			return err
+		}
+
+		rule.Name = aws.StringValue(result.Name)
+		predicates = result.Predicates
+	}
+
+	for _, p := range predicates {
+		predicateType := aws.StringValue(p.Type)
+		dataID := aws.StringValue(p.DataId)
+
+		if predicateType == waf.PredicateTypeIpmatch {
+			data, err := reader.get(predicateType+"/"+dataID, func() (interface{}, error) {
+				return c.getClassicIPDataSet(dataID, scope)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get IP set %s: %w", dataID, err)
+			}
+
+			ipSet := data.(schema.IPDataSet)
+			ipSet.Negated = aws.BoolValue(p.Negated)
+			rule.IPDataSet = append(rule.IPDataSet, ipSet)
+			rule.Conditions = append(rule.Conditions, schema.RuleCondition{
+				Type:    predicateType,
+				DataID:  dataID,
+				Name:    ipSet.Name,
+				Negated: ipSet.Negated,
+				Matches: []string{fmt.Sprintf("%d addresses", len(ipSet.IPList))},
+			})
+			continue
+		}
+
+		data, err := reader.get(predicateType+"/"+dataID, func() (interface{}, error) {
+			return c.describeClassicPredicate(reader, predicateType, dataID, scope)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get %s condition %s: %w", predicateType, dataID, err)
+		}
+
+		condition := data.(schema.RuleCondition)
+		condition.Negated = aws.BoolValue(p.Negated)
+		rule.Conditions = append(rule.Conditions, condition)
+	}
+
+	if len(predicates) > 1 {
+		addOperator(rule.Conditions, constants.AndOperator)
+	}
+
+	return nil
+}
+
+// describeClassicPredicate retrieves match settings of WAF Classic condition other than IP set
+func (c Client) describeClassicPredicate(reader *wafReader, predicateType, dataID, scope string) (schema.RuleCondition, error) {
+	client := c.classicWafClient(scope)
+	condition := schema.RuleCondition{
+		Type:   predicateType,
+		DataID: dataID,
+	}
+
+	switch predicateType {
+	case waf.PredicateTypeByteMatch:
+		result, err := client.GetByteMatchSet(&waf.GetByteMatchSetInput{ByteMatchSetId: aws.String(dataID)})
+		if err != nil {
+			return condition, err
+		}
+
+		condition.Name = aws.StringValue(result.ByteMatchSet.Name)
+		for _, tuple := range result.ByteMatchSet.ByteMatchTuples {
+			setting := fmt.Sprintf("%s %q", aws.StringValue(tuple.PositionalConstraint), string(tuple.TargetString))
+			condition.Matches = append(condition.Matches, formatMatch(formatClassicField(tuple.FieldToMatch), setting, aws.StringValue(tuple.TextTransformation)))
+		}
+	case waf.PredicateTypeGeoMatch:
+		result, err := client.GetGeoMatchSet(&waf.GetGeoMatchSetInput{GeoMatchSetId: aws.String(dataID)})
+		if err != nil {
+			return condition, err
+		}
+
+		condition.Name = aws.StringValue(result.GeoMatchSet.Name)
+		for _, constraint := range result.GeoMatchSet.GeoMatchConstraints {
+			condition.Matches = append(condition.Matches, fmt.Sprintf("%s %s", aws.StringValue(constraint.Type), aws.StringValue(constraint.Value)))
+		}
+	case waf.PredicateTypeSqlInjectionMatch:
+		result, err := client.GetSqlInjectionMatchSet(&waf.GetSqlInjectionMatchSetInput{SqlInjectionMatchSetId: aws.String(dataID)})
+		if err != nil {
+			return condition, err
+		}
+
+		condition.Name = aws.StringValue(result.SqlInjectionMatchSet.Name)
+		for _, tuple := range result.SqlInjectionMatchSet.SqlInjectionMatchTuples {
+			condition.Matches = append(condition.Matches, formatMatch(formatClassicField(tuple.FieldToMatch), constants.EmptyString, aws.StringValue(tuple.TextTransformation)))
+		}
+	case waf.PredicateTypeXssMatch:
+		result, err := client.GetXssMatchSet(&waf.GetXssMatchSetInput{XssMatchSetId: aws.String(dataID)})
+		if err != nil {
+			return condition, err
+		}
+
+		condition.Name = aws.StringValue(result.XssMatchSet.Name)
+		for _, tuple := range result.XssMatchSet.XssMatchTuples {
+			condition.Matches = append(condition.Matches, formatMatch(formatClassicField(tuple.FieldToMatch), constants.EmptyString, aws.StringValue(tuple.TextTransformation)))
+		}
+	case waf.PredicateTypeSizeConstraint:
+		result, err := client.GetSizeConstraintSet(&waf.GetSizeConstraintSetInput{SizeConstraintSetId: aws.String(dataID)})
+		if err != nil {
+			return condition, err
+		}
+
+		condition.Name = aws.StringValue(result.SizeConstraintSet.Name)
+		for _, constraint := range result.SizeConstraintSet.SizeConstraints {
+			setting := fmt.Sprintf("%s %d", aws.StringValue(constraint.ComparisonOperator), aws.Int64Value(constraint.Size))
+			condition.Matches = append(condition.Matches, formatMatch(formatClassicField(constraint.FieldToMatch), setting, aws.StringValue(constraint.TextTransformation)))
+		}
+	case waf.PredicateTypeRegexMatch:
+		result, err := client.GetRegexMatchSet(&waf.GetRegexMatchSetInput{RegexMatchSetId: aws.String(dataID)})
+		if err != nil {
+			return condition, err
+		}
+
+		condition.Name = aws.StringValue(result.RegexMatchSet.Name)
+		for _, tuple := range result.RegexMatchSet.RegexMatchTuples {
+			patternSetID := aws.StringValue(tuple.RegexPatternSetId)
+			patterns, err := reader.get("RegexPatternSet/"+patternSetID, func() (interface{}, error) {
+				return client.GetRegexPatternSet(&waf.GetRegexPatternSetInput{RegexPatternSetId: aws.String(patternSetID)})
+			})
+			if err != nil {
+				return condition, err
+			}
+
+			setting := fmt.Sprintf("MATCHES %s", strings.Join(aws.StringValueSlice(patterns.(*waf.GetRegexPatternSetOutput).RegexPatternSet.RegexPatternStrings), ", "))
+			condition.Matches = append(condition.Matches, formatMatch(formatClassicField(tuple.FieldToMatch), setting, aws.StringValue(tuple.TextTransformation)))
+		}
+	default:
+		// predicate which is not supported is shown with its type only
+	}
+
+	return condition, nil
+}
+
+// formatClassicField returns part of request which is inspected by WAF Classic, e.g. HEADER:User-Agent
+func formatClassicField(field *waf.FieldToMatch) string {
+	if field == nil {
+		return constants.EmptyString
+	}
+
+	if len(aws.StringValue(field.Data)) > 0 {
+		return fmt.Sprintf("%s:%s", aws.StringValue(field.Type), aws.StringValue(field.Data))
+	}
+
+	return aws.StringValue(field.Type)
+}
+
+// formatMatch returns match setting of condition with text transformations
+func formatMatch(field, setting string, transformations ...string) string {
+	ret := strings.TrimSpace(fmt.Sprintf("%s %s", field, setting))
+
+	var applied []string
+	for _, transformation := range transformations {
+		if len(transformation) > 0 && transformation != waf.TextTransformationNone {
+			applied = append(applied, transformation)
+		}
+	}
+
+	if len(applied) > 0 {
+		ret = fmt.Sprintf("%s (%s)", ret, strings.Join(applied, ", "))
+	}
+
+	return ret
+}
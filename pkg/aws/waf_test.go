@@ -1,21 +1,129 @@
 package aws
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
 
-func TestParseWebACLID(t *testing.T) {
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/aws/aws-sdk-go/service/wafregional"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestGetWebACLLabel(t *testing.T) {
 	testData := []struct {
-		input    string
+		input    schema.WebACLSummary
 		expected string
 	}{
 		{
-			input:    "test-web-acl / 1234-1234-1234",
-			expected: "1234-1234-1234",
+			input:    schema.WebACLSummary{Version: "classic", Scope: "CLOUDFRONT"},
+			expected: "WAF Classic CLOUDFRONT",
+		},
+		{
+			input:    schema.WebACLSummary{Version: "classic", Scope: "REGIONAL"},
+			expected: "WAF Regional",
+		},
+		{
+			input:    schema.WebACLSummary{Version: "v2", Scope: "REGIONAL"},
+			expected: "WAFv2 REGIONAL",
 		},
 	}
 
 	for _, td := range testData {
-		if ParseWebACLID(td.input) != td.expected {
-			t.Errorf("expected: %s, output: %s", td.expected, ParseWebACLID(td.input))
+		if output := GetWebACLLabel(td.input); output != td.expected {
+			t.Errorf("expected: %s, output: %s", td.expected, output)
 		}
 	}
 }
+
+func TestApplyAddressChanges(t *testing.T) {
+	output := ApplyAddressChanges([]string{"1.1.1.1/32", "2.2.2.2/32"}, []string{"3.3.3.3/32", "1.1.1.1/32"}, []string{"2.2.2.2/32"})
+	expected := []string{"1.1.1.1/32", "3.3.3.3/32"}
+	if len(output) != len(expected) {
+		t.Fatalf("expected: %v, output: %v", expected, output)
+	}
+
+	for i := range expected {
+		if output[i] != expected[i] {
+			t.Errorf("expected: %v, output: %v", expected, output)
+		}
+	}
+}
+
+func TestListWebACLsSkipsFailedAPI(t *testing.T) {
+	denied := newDeniedWafServer()
+	defer denied.Close()
+
+	ok := newWafServer(`{"WebACLs":[{"WebACLId":"1234","Name":"alb-acl"}]}`)
+	defer ok.Close()
+
+	ACLs, err := newClassicWafClient(denied.URL, ok.URL).ListWebACLs(constants.ClassicWafVersion, constants.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ACLs) != 1 || ACLs[0].Scope != constants.RegionalScope {
+		t.Errorf("web ACLs of WAF Regional should be listed: %+v", ACLs)
+	}
+
+	if _, err := newClassicWafClient(denied.URL, denied.URL).ListWebACLs(constants.ClassicWafVersion, constants.EmptyString); err == nil {
+		t.Errorf("error should be returned if all APIs fail")
+	}
+}
+
+func TestListIPSetsSkipsFailedAPI(t *testing.T) {
+	denied := newDeniedWafServer()
+	defer denied.Close()
+
+	ok := newWafServer(`{"IPSets":[{"IPSetId":"1234","Name":"office"}]}`)
+	defer ok.Close()
+
+	ipSets, err := newClassicWafClient(denied.URL, ok.URL).ListIPSets(constants.ClassicWafVersion, constants.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ipSets) != 1 || ipSets[0].Scope != constants.RegionalScope {
+		t.Errorf("IP sets of WAF Regional should be listed: %+v", ipSets)
+	}
+
+	if _, err := newClassicWafClient(denied.URL, denied.URL).ListIPSets(constants.ClassicWafVersion, constants.EmptyString); err == nil {
+		t.Errorf("error should be returned if all APIs fail")
+	}
+}
+
+// newWafServer returns server which responds with the body
+func newWafServer(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.Write([]byte(body))
+	}))
+}
+
+// newDeniedWafServer returns server which denies every request
+func newDeniedWafServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"AccessDeniedException","message":"access denied"}`))
+	}))
+}
+
+// newClassicWafClient returns client of WAF Classic with endpoints of global and regional API
+func newClassicWafClient(global, regional string) Client {
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("ap-northeast-2"),
+		Credentials: credentials.NewStaticCredentials("AKIAEXAMPLE", "secret", ""),
+		MaxRetries:  aws.Int(0),
+	}))
+
+	return Client{
+		WafClient:         waf.New(sess, &aws.Config{Endpoint: aws.String(global)}),
+		WafRegionalClient: wafregional.New(sess, &aws.Config{Endpoint: aws.String(regional)}),
+	}
+}
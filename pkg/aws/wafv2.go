@@ -0,0 +1,245 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func GetWafV2ClientFn(sess client.ConfigProvider, region string, creds *credentials.Credentials) *wafv2.WAFV2 {
+	if creds == nil {
+		return wafv2.New(sess, &aws.Config{Region: aws.String(region)})
+	}
+	return wafv2.New(sess, &aws.Config{Region: aws.String(region), Credentials: creds})
+}
+
+// wafV2Client returns WAFv2 client of scope, API of CloudFront scope is only served in us-east-1
+func (c Client) wafV2Client(scope string) *wafv2.WAFV2 {
+	if scope == constants.CloudFrontScope {
+		return c.WafV2GlobalClient
+	}
+	return c.WafV2Client
+}
+
+// GetAllWebACLsV2 retrieves all WAFv2 web ACLs of scope
+func (c Client) GetAllWebACLsV2(scope string) ([]schema.WebACLSummary, error) {
+	input := &wafv2.ListWebACLsInput{
+		Limit: aws.Int64(constants.MaxWafListLimit),
+		Scope: aws.String(scope),
+	}
+
+	var ACLs []schema.WebACLSummary
+	for {
+		result, err := c.wafV2Client(scope).ListWebACLs(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, acl := range result.WebACLs {
+			ACLs = append(ACLs, schema.WebACLSummary{
+				ID:      *acl.Id,
+				Name:    *acl.Name,
+				ARN:     *acl.ARN,
+				Version: constants.WafV2Version,
+				Scope:   scope,
+			})
+		}
+
+		if result.NextMarker == nil {
+			break
+		}
+		input.NextMarker = result.NextMarker
+	}
+
+	return ACLs, nil
+}
+
+// DescribeWebACLV2 describes WAFv2 web acl with rule groups and IP sets in its rules
+func (c Client) DescribeWebACLV2(summary schema.WebACLSummary, limiter WafLimiter) (*schema.WebACL, error) {
+	var result *wafv2.GetWebACLOutput
+	err := limiter.do(func() error {
+		var err error
+		result, err = c.wafV2Client(summary.Scope).GetWebACL(&wafv2.GetWebACLInput{
+			Id:    aws.String(summary.ID),
+			Name:  aws.String(summary.Name),
+			Scope: aws.String(summary.Scope),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get web ACL %s: %w", summary.Name, err)
+	}
+
+	info := result.WebACL
+	ret := schema.WebACL{
+		ID:            *info.Id,
+		Name:          *info.Name,
+		Version:       constants.WafV2Version,
+		Scope:         summary.Scope,
+		DefaultAction: getDefaultActionV2(info.DefaultAction),
+	}
+
+	reader := newWafReader(limiter)
+	ret.Rules = make([]schema.ACLRule, len(info.Rules))
+	err = reader.run(len(info.Rules), func(i int) error {
+		rule := info.Rules[i]
+		aclRule := schema.ACLRule{
+			Type:       getStatementTypeV2(rule.Statement),
+			ActionType: getRuleActionV2(rule),
+			Priority:   *rule.Priority,
+			RuleID:     *rule.Name,
+			Name:       *rule.Name,
+		}
+
+		if err := c.describeStatementV2(reader, &aclRule, rule.Statement, false); err != nil {
+			return fmt.Errorf("failed to get rule %s: %w", aclRule.Name, err)
+		}
+
+		ret.Rules[i] = aclRule
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetRuleGroupV2 retrieves WAFv2 rule group
+func (c Client) GetRuleGroupV2(ruleGroupArn string) (*wafv2.RuleGroup, error) {
+	scope, name, id, err := ParseWafV2ARN(ruleGroupArn)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.wafV2Client(scope).GetRuleGroup(&wafv2.GetRuleGroupInput{
+		Id:    aws.String(id),
+		Name:  aws.String(name),
+		Scope: aws.String(scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.RuleGroup, nil
+}
+
+// GetIPSetV2 retrieves addresses of WAFv2 IP set
+func (c Client) GetIPSetV2(ipSetArn string) (*schema.IPDataSet, error) {
+	scope, name, id, err := ParseWafV2ARN(ipSetArn)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.wafV2Client(scope).GetIPSet(&wafv2.GetIPSetInput{
+		Id:    aws.String(id),
+		Name:  aws.String(name),
+		Scope: aws.String(scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.IPDataSet{
+		ID:     *result.IPSet.Id,
+		Name:   *result.IPSet.Name,
+		IPList: aws.StringValueSlice(result.IPSet.Addresses),
+	}, nil
+}
+
+// ParseWafV2ARN parses scope, name and id from arn of WAFv2 resource
+// resource: regional/ipset/<name>/<id> or global/rulegroup/<name>/<id>
+func ParseWafV2ARN(resourceArn string) (string, string, string, error) {
+	parsed, err := arn.Parse(resourceArn)
+	if err != nil {
+		return constants.EmptyString, constants.EmptyString, constants.EmptyString, err
+	}
+
+	parts := strings.Split(parsed.Resource, "/")
+	if len(parts) != 4 {
+		return constants.EmptyString, constants.EmptyString, constants.EmptyString, fmt.Errorf("wrong WAFv2 arn: %s", resourceArn)
+	}
+
+	scope := constants.RegionalScope
+	if parts[0] == "global" {
+		scope = constants.CloudFrontScope
+	}
+
+	return scope, parts[2], parts[3], nil
+}
+
+// getDefaultActionV2 returns default action of web ACL
+func getDefaultActionV2(action *wafv2.DefaultAction) string {
+	if action != nil && action.Block != nil {
+		return waf.WafActionTypeBlock
+	}
+	return waf.WafActionTypeAllow
+}
+
+// getRuleActionV2 returns action of rule, override action is used for rule group
+func getRuleActionV2(rule *wafv2.Rule) string {
+	if rule.Action != nil {
+		switch {
+		case rule.Action.Block != nil:
+			return waf.WafActionTypeBlock
+		case rule.Action.Count != nil:
+			return waf.WafActionTypeCount
+		case rule.Action.Allow != nil:
+			return waf.WafActionTypeAllow
+		default:
+			// unknown action is not regarded as allow, so that it does not decide the result
+			return constants.UnknownWafAction
+		}
+	}
+
+	if rule.OverrideAction != nil && rule.OverrideAction.Count != nil {
+		return waf.WafActionTypeCount
+	}
+
+	return "NONE"
+}
+
+// getStatementTypeV2 returns type of rule statement
+func getStatementTypeV2(statement *wafv2.Statement) string {
+	switch {
+	case statement == nil:
+		return constants.EmptyString
+	case statement.IPSetReferenceStatement != nil:
+		return "IP_SET"
+	case statement.RuleGroupReferenceStatement != nil:
+		return "RULE_GROUP"
+	case statement.ManagedRuleGroupStatement != nil:
+		return "MANAGED_RULE_GROUP"
+	case statement.RateBasedStatement != nil:
+		return "RATE_BASED"
+	case statement.AndStatement != nil:
+		return "AND"
+	case statement.OrStatement != nil:
+		return "OR"
+	case statement.NotStatement != nil:
+		return "NOT"
+	case statement.GeoMatchStatement != nil:
+		return "GEO_MATCH"
+	case statement.ByteMatchStatement != nil:
+		return "BYTE_MATCH"
+	case statement.SqliMatchStatement != nil:
+		return "SQLI_MATCH"
+	case statement.XssMatchStatement != nil:
+		return "XSS_MATCH"
+	case statement.SizeConstraintStatement != nil:
+		return "SIZE_CONSTRAINT"
+	case statement.RegexPatternSetReferenceStatement != nil:
+		return "REGEX_PATTERN_SET"
+	}
+
+	return "UNKNOWN"
+}
@@ -0,0 +1,247 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+// describeStatementV2 adds conditions of WAFv2 statement to rule
+// Nested statements are flattened with the operator of AND or OR statement, and statements in NotStatement are negated.
+func (c Client) describeStatementV2(reader *wafReader, rule *schema.ACLRule, statement *wafv2.Statement, negated bool) error {
+	if statement == nil {
+		return nil
+	}
+
+	switch {
+	case statement.AndStatement != nil:
+		return c.describeStatementsV2(reader, rule, statement.AndStatement.Statements, constants.AndOperator, negated)
+	case statement.OrStatement != nil:
+		return c.describeStatementsV2(reader, rule, statement.OrStatement.Statements, constants.OrOperator, negated)
+	case statement.NotStatement != nil:
+		return c.describeStatementV2(reader, rule, statement.NotStatement.Statement, !negated)
+	case statement.RateBasedStatement != nil:
+		rule.RateKey = aws.StringValue(statement.RateBasedStatement.AggregateKeyType)
+		rule.RateLimit = aws.Int64Value(statement.RateBasedStatement.Limit)
+		return c.describeStatementV2(reader, rule, statement.RateBasedStatement.ScopeDownStatement, negated)
+	case statement.IPSetReferenceStatement != nil:
+		ipSetArn := aws.StringValue(statement.IPSetReferenceStatement.ARN)
+		data, err := reader.get(ipSetArn, func() (interface{}, error) {
+			return c.GetIPSetV2(ipSetArn)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get IP set %s: %w", ipSetArn, err)
+		}
+
+		ipSet := *data.(*schema.IPDataSet)
+		ipSet.Negated = negated
+		rule.IPDataSet = append(rule.IPDataSet, ipSet)
+		rule.Conditions = append(rule.Conditions, schema.RuleCondition{
+			Type:    waf.PredicateTypeIpmatch,
+			DataID:  ipSet.ID,
+			Name:    ipSet.Name,
+			Negated: negated,
+			Matches: []string{fmt.Sprintf("%d addresses", len(ipSet.IPList))},
+		})
+		return nil
+	case statement.RuleGroupReferenceStatement != nil:
+		return c.describeRuleGroupV2(reader, rule, aws.StringValue(statement.RuleGroupReferenceStatement.ARN), negated)
+	case statement.RegexPatternSetReferenceStatement != nil:
+		return c.describeRegexPatternSetV2(reader, rule, statement.RegexPatternSetReferenceStatement, negated)
+	}
+
+	condition := describeMatchStatementV2(statement)
+	condition.Negated = negated
+	rule.Conditions = append(rule.Conditions, condition)
+
+	return nil
+}
+
+// describeStatementsV2 adds conditions of statements combined with operator to rule
+func (c Client) describeStatementsV2(reader *wafReader, rule *schema.ACLRule, statements []*wafv2.Statement, operator string, negated bool) error {
+	start := len(rule.Conditions)
+	for _, statement := range statements {
+		if err := c.describeStatementV2(reader, rule, statement, negated); err != nil {
+			return err
+		}
+	}
+
+	addOperator(rule.Conditions[start:], operator)
+
+	return nil
+}
+
+// addOperator prepends operator of the outer statement to conditions
+func addOperator(conditions []schema.RuleCondition, operator string) {
+	for i := range conditions {
+		if len(conditions[i].Operator) == 0 {
+			conditions[i].Operator = operator
+			continue
+		}
+		conditions[i].Operator = fmt.Sprintf("%s > %s", operator, conditions[i].Operator)
+	}
+}
+
+// describeRuleGroupV2 adds rule group to conditions of rule, IP sets in the rule group are also added to rule
+func (c Client) describeRuleGroupV2(reader *wafReader, rule *schema.ACLRule, ruleGroupArn string, negated bool) error {
+	data, err := reader.get(ruleGroupArn, func() (interface{}, error) {
+		return c.GetRuleGroupV2(ruleGroupArn)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get rule group %s: %w", ruleGroupArn, err)
+	}
+
+	group := data.(*wafv2.RuleGroup)
+	rule.Conditions = append(rule.Conditions, schema.RuleCondition{
+		Type:    constants.RuleGroupCondition,
+		DataID:  aws.StringValue(group.Id),
+		Name:    aws.StringValue(group.Name),
+		Negated: negated,
+		Matches: []string{fmt.Sprintf("%d rules", len(group.Rules))},
+	})
+
+	for _, groupRule := range group.Rules {
+		var inner schema.ACLRule
+		if err := c.describeStatementV2(reader, &inner, groupRule.Statement, negated); err != nil {
+			return err
+		}
+		rule.IPDataSet = append(rule.IPDataSet, inner.IPDataSet...)
+	}
+
+	return nil
+}
+
+// describeRegexPatternSetV2 adds regex pattern set with its patterns to conditions of rule
+func (c Client) describeRegexPatternSetV2(reader *wafReader, rule *schema.ACLRule, statement *wafv2.RegexPatternSetReferenceStatement, negated bool) error {
+	patternSetArn := aws.StringValue(statement.ARN)
+	data, err := reader.get(patternSetArn, func() (interface{}, error) {
+		return c.GetRegexPatternSetV2(patternSetArn)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get regex pattern set %s: %w", patternSetArn, err)
+	}
+
+	patternSet := data.(*wafv2.RegexPatternSet)
+	var patterns []string
+	for _, regex := range patternSet.RegularExpressionList {
+		patterns = append(patterns, aws.StringValue(regex.RegexString))
+	}
+
+	setting := fmt.Sprintf("MATCHES %s", strings.Join(patterns, ", "))
+	rule.Conditions = append(rule.Conditions, schema.RuleCondition{
+		Type:    waf.PredicateTypeRegexMatch,
+		DataID:  aws.StringValue(patternSet.Id),
+		Name:    aws.StringValue(patternSet.Name),
+		Negated: negated,
+		Matches: []string{formatMatch(formatFieldV2(statement.FieldToMatch), setting, getTextTransformationsV2(statement.TextTransformations)...)},
+	})
+
+	return nil
+}
+
+// GetRegexPatternSetV2 retrieves WAFv2 regex pattern set
+func (c Client) GetRegexPatternSetV2(patternSetArn string) (*wafv2.RegexPatternSet, error) {
+	scope, name, id, err := ParseWafV2ARN(patternSetArn)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.wafV2Client(scope).GetRegexPatternSet(&wafv2.GetRegexPatternSetInput{
+		Id:    aws.String(id),
+		Name:  aws.String(name),
+		Scope: aws.String(scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.RegexPatternSet, nil
+}
+
+// describeMatchStatementV2 returns condition of statement which does not refer to other resources
+func describeMatchStatementV2(statement *wafv2.Statement) schema.RuleCondition {
+	switch {
+	case statement.ByteMatchStatement != nil:
+		s := statement.ByteMatchStatement
+		setting := fmt.Sprintf("%s %q", aws.StringValue(s.PositionalConstraint), string(s.SearchString))
+		return schema.RuleCondition{
+			Type:    waf.PredicateTypeByteMatch,
+			Matches: []string{formatMatch(formatFieldV2(s.FieldToMatch), setting, getTextTransformationsV2(s.TextTransformations)...)},
+		}
+	case statement.GeoMatchStatement != nil:
+		condition := schema.RuleCondition{Type: waf.PredicateTypeGeoMatch}
+		for _, code := range statement.GeoMatchStatement.CountryCodes {
+			condition.Matches = append(condition.Matches, fmt.Sprintf("%s %s", waf.GeoMatchConstraintTypeCountry, aws.StringValue(code)))
+		}
+		return condition
+	case statement.SqliMatchStatement != nil:
+		s := statement.SqliMatchStatement
+		return schema.RuleCondition{
+			Type:    waf.PredicateTypeSqlInjectionMatch,
+			Matches: []string{formatMatch(formatFieldV2(s.FieldToMatch), constants.EmptyString, getTextTransformationsV2(s.TextTransformations)...)},
+		}
+	case statement.XssMatchStatement != nil:
+		s := statement.XssMatchStatement
+		return schema.RuleCondition{
+			Type:    waf.PredicateTypeXssMatch,
+			Matches: []string{formatMatch(formatFieldV2(s.FieldToMatch), constants.EmptyString, getTextTransformationsV2(s.TextTransformations)...)},
+		}
+	case statement.SizeConstraintStatement != nil:
+		s := statement.SizeConstraintStatement
+		setting := fmt.Sprintf("%s %d", aws.StringValue(s.ComparisonOperator), aws.Int64Value(s.Size))
+		return schema.RuleCondition{
+			Type:    waf.PredicateTypeSizeConstraint,
+			Matches: []string{formatMatch(formatFieldV2(s.FieldToMatch), setting, getTextTransformationsV2(s.TextTransformations)...)},
+		}
+	case statement.ManagedRuleGroupStatement != nil:
+		s := statement.ManagedRuleGroupStatement
+		return schema.RuleCondition{
+			Type:    constants.ManagedRuleGroupCondition,
+			Name:    fmt.Sprintf("%s/%s", aws.StringValue(s.VendorName), aws.StringValue(s.Name)),
+			Matches: []string{fmt.Sprintf("%d excluded rules", len(s.ExcludedRules))},
+		}
+	}
+
+	// statement which is not supported is shown with its type only
+	return schema.RuleCondition{Type: getStatementTypeV2(statement)}
+}
+
+// formatFieldV2 returns part of request which is inspected by WAFv2, in the same format as WAF Classic
+func formatFieldV2(field *wafv2.FieldToMatch) string {
+	switch {
+	case field == nil:
+		return constants.EmptyString
+	case field.SingleHeader != nil:
+		return fmt.Sprintf("%s:%s", waf.MatchFieldTypeHeader, aws.StringValue(field.SingleHeader.Name))
+	case field.SingleQueryArgument != nil:
+		return fmt.Sprintf("%s:%s", waf.MatchFieldTypeSingleQueryArg, aws.StringValue(field.SingleQueryArgument.Name))
+	case field.AllQueryArguments != nil:
+		return waf.MatchFieldTypeAllQueryArgs
+	case field.QueryString != nil:
+		return waf.MatchFieldTypeQueryString
+	case field.UriPath != nil:
+		return waf.MatchFieldTypeUri
+	case field.Body != nil:
+		return waf.MatchFieldTypeBody
+	case field.Method != nil:
+		return waf.MatchFieldTypeMethod
+	}
+
+	return "UNKNOWN"
+}
+
+// getTextTransformationsV2 returns types of text transformations
+func getTextTransformationsV2(transformations []*wafv2.TextTransformation) []string {
+	var ret []string
+	for _, transformation := range transformations {
+		ret = append(ret, aws.StringValue(transformation.Type))
+	}
+
+	return ret
+}
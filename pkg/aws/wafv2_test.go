@@ -0,0 +1,175 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestParseWafV2ARN(t *testing.T) {
+	testData := []struct {
+		input string
+		scope string
+		name  string
+		id    string
+		err   bool
+	}{
+		{
+			input: "arn:aws:wafv2:ap-northeast-2:123456789012:regional/ipset/office/a1b2c3d4-5678-90ab-cdef-EXAMPLE11111",
+			scope: "REGIONAL",
+			name:  "office",
+			id:    "a1b2c3d4-5678-90ab-cdef-EXAMPLE11111",
+		},
+		{
+			input: "arn:aws:wafv2:us-east-1:123456789012:global/rulegroup/partner/a1b2c3d4-5678-90ab-cdef-EXAMPLE22222",
+			scope: "CLOUDFRONT",
+			name:  "partner",
+			id:    "a1b2c3d4-5678-90ab-cdef-EXAMPLE22222",
+		},
+		{
+			input: "arn:aws:wafv2:us-east-1:123456789012:global/ipset/partner",
+			err:   true,
+		},
+	}
+
+	for _, td := range testData {
+		scope, name, id, err := ParseWafV2ARN(td.input)
+		if td.err {
+			if err == nil {
+				t.Errorf("expected error: %s", td.input)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if scope != td.scope || name != td.name || id != td.id {
+			t.Errorf("expected %s %s %s, output %s %s %s", td.scope, td.name, td.id, scope, name, id)
+		}
+	}
+}
+
+func TestGetRuleActionV2(t *testing.T) {
+	testData := []struct {
+		input    *wafv2.Rule
+		expected string
+	}{
+		{
+			input:    &wafv2.Rule{Action: &wafv2.RuleAction{Block: &wafv2.BlockAction{}}},
+			expected: "BLOCK",
+		},
+		{
+			input:    &wafv2.Rule{Action: &wafv2.RuleAction{Allow: &wafv2.AllowAction{}}},
+			expected: "ALLOW",
+		},
+		{
+			input:    &wafv2.Rule{Action: &wafv2.RuleAction{}},
+			expected: "UNKNOWN",
+		},
+		{
+			input:    &wafv2.Rule{OverrideAction: &wafv2.OverrideAction{Count: &wafv2.CountAction{}}},
+			expected: "COUNT",
+		},
+		{
+			input:    &wafv2.Rule{OverrideAction: &wafv2.OverrideAction{None: &wafv2.NoneAction{}}},
+			expected: "NONE",
+		},
+	}
+
+	for _, td := range testData {
+		if output := getRuleActionV2(td.input); output != td.expected {
+			t.Errorf("expected %s, output %s", td.expected, output)
+		}
+	}
+}
+
+func TestDescribeMatchStatementV2(t *testing.T) {
+	testData := []struct {
+		input    *wafv2.Statement
+		kind     string
+		expected []string
+	}{
+		{
+			input: &wafv2.Statement{ByteMatchStatement: &wafv2.ByteMatchStatement{
+				FieldToMatch:         &wafv2.FieldToMatch{UriPath: &wafv2.UriPath{}},
+				PositionalConstraint: aws.String("STARTS_WITH"),
+				SearchString:         []byte("/admin"),
+				TextTransformations:  []*wafv2.TextTransformation{{Priority: aws.Int64(0), Type: aws.String("LOWERCASE")}},
+			}},
+			kind:     "ByteMatch",
+			expected: []string{`URI STARTS_WITH "/admin" (LOWERCASE)`},
+		},
+		{
+			input:    &wafv2.Statement{GeoMatchStatement: &wafv2.GeoMatchStatement{CountryCodes: aws.StringSlice([]string{"KR", "JP"})}},
+			kind:     "GeoMatch",
+			expected: []string{"Country KR", "Country JP"},
+		},
+		{
+			input: &wafv2.Statement{SqliMatchStatement: &wafv2.SqliMatchStatement{
+				FieldToMatch:        &wafv2.FieldToMatch{SingleHeader: &wafv2.SingleHeader{Name: aws.String("User-Agent")}},
+				TextTransformations: []*wafv2.TextTransformation{{Priority: aws.Int64(0), Type: aws.String("NONE")}},
+			}},
+			kind:     "SqlInjectionMatch",
+			expected: []string{"HEADER:User-Agent"},
+		},
+		{
+			input: &wafv2.Statement{SizeConstraintStatement: &wafv2.SizeConstraintStatement{
+				ComparisonOperator: aws.String("GT"),
+				FieldToMatch:       &wafv2.FieldToMatch{Body: &wafv2.Body{}},
+				Size:               aws.Int64(8192),
+			}},
+			kind:     "SizeConstraint",
+			expected: []string{"BODY GT 8192"},
+		},
+	}
+
+	for _, td := range testData {
+		condition := describeMatchStatementV2(td.input)
+		if condition.Type != td.kind {
+			t.Errorf("expected type %s, output %s", td.kind, condition.Type)
+		}
+
+		if strings.Join(condition.Matches, "|") != strings.Join(td.expected, "|") {
+			t.Errorf("expected: %v, output: %v", td.expected, condition.Matches)
+		}
+	}
+}
+
+func TestDescribeStatementV2Operator(t *testing.T) {
+	geo := &wafv2.Statement{GeoMatchStatement: &wafv2.GeoMatchStatement{CountryCodes: aws.StringSlice([]string{"KR"})}}
+	size := &wafv2.Statement{SizeConstraintStatement: &wafv2.SizeConstraintStatement{
+		ComparisonOperator: aws.String("GT"),
+		FieldToMatch:       &wafv2.FieldToMatch{Body: &wafv2.Body{}},
+		Size:               aws.Int64(8192),
+	}}
+	statement := &wafv2.Statement{AndStatement: &wafv2.AndStatement{Statements: []*wafv2.Statement{
+		{OrStatement: &wafv2.OrStatement{Statements: []*wafv2.Statement{geo, size}}},
+		{NotStatement: &wafv2.NotStatement{Statement: geo}},
+	}}}
+
+	var rule schema.ACLRule
+	if err := (Client{}).describeStatementV2(newWafReader(NewWafLimiter(1)), &rule, statement, false); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"AND > OR", "AND > OR", "AND"}
+	if len(rule.Conditions) != len(expected) {
+		t.Fatalf("expected %d conditions, output %+v", len(expected), rule.Conditions)
+	}
+
+	for i, condition := range rule.Conditions {
+		if condition.Operator != expected[i] {
+			t.Errorf("expected operator %s, output %s", expected[i], condition.Operator)
+		}
+	}
+
+	if !rule.Conditions[2].Negated {
+		t.Errorf("condition in NOT statement should be negated")
+	}
+}
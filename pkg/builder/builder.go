@@ -13,6 +13,27 @@ type Flags struct {
 	Region   string `json:"region"`
 	Duration int    `json:"duration"`
 	Profile  string `json:"profile"`
+	Address  string `json:"address"`
+	Port     int    `json:"port"`
+	Token    string `json:"token"`
+	Format   string `json:"format"`
+	Service  string `json:"service"`
+	Open     bool   `json:"open"`
+	Wait     bool   `json:"wait"`
+	Yes      bool   `json:"yes"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+
+	WafVersion  string `json:"waf_version"`
+	Scope       string `json:"scope"`
+	IPSet       string `json:"ip_set"`
+	WebACL      string `json:"web_acl"`
+	File        string `json:"file"`
+	FileFormat  string `json:"file_format"`
+	Prune       bool   `json:"prune"`
+	AllowEmpty  bool   `json:"allow_empty"`
+	All         bool   `json:"all"`
+	Concurrency int    `json:"concurrency"`
 }
 
 func ParseFlags() (*Flags, error) {
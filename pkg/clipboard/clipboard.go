@@ -0,0 +1,115 @@
+package clipboard
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+)
+
+// ErrPasteNotSupported is returned when the content of clipboard cannot be read
+var ErrPasteNotSupported = errors.New("reading clipboard is not supported")
+
+// Clipboard is a backend of system clipboard
+type Clipboard interface {
+	Name() string
+	Copy(text string) error
+	// Paste returns the content of clipboard, or ErrPasteNotSupported
+	Paste() (string, error)
+	Clear() error
+}
+
+// New creates clipboard with the name of backend, or detects one if name is empty
+func New(name string) (Clipboard, error) {
+	switch name {
+	case constants.EmptyString, constants.AutoClipboard:
+		return detect(os.Getenv, exec.LookPath)
+	case constants.PbcopyClipboard:
+		return pbcopy(), nil
+	case constants.WlCopyClipboard:
+		return wlCopy(), nil
+	case constants.XclipClipboard:
+		return xclip(), nil
+	case constants.XselClipboard:
+		return xsel(), nil
+	case constants.OSC52Clipboard:
+		return newOSC52(os.Getenv("TMUX") != constants.EmptyString), nil
+	}
+
+	return nil, fmt.Errorf("clipboard is not supported: %s", name)
+}
+
+// detect finds available clipboard of current environment
+func detect(getenv func(string) string, lookPath func(string) (string, error)) (Clipboard, error) {
+	exists := func(file string) bool {
+		_, err := lookPath(file)
+		return err == nil
+	}
+
+	remote := getenv("SSH_TTY") != constants.EmptyString || getenv("SSH_CONNECTION") != constants.EmptyString
+	tmux := getenv("TMUX") != constants.EmptyString
+
+	switch {
+	case runtime.GOOS == "darwin" && exists("pbcopy"):
+		return pbcopy(), nil
+	case getenv("WAYLAND_DISPLAY") != constants.EmptyString && exists("wl-copy"):
+		return wlCopy(), nil
+	case getenv("DISPLAY") != constants.EmptyString && exists("xclip"):
+		return xclip(), nil
+	case getenv("DISPLAY") != constants.EmptyString && exists("xsel"):
+		return xsel(), nil
+	case remote || tmux:
+		// OSC 52 is used only without clipboard tool, because text is lost silently if terminal does not support it
+		logrus.Warnf("no clipboard tool is available, text is copied with OSC 52 which works only if your terminal supports it. set clipboard.backend to choose one")
+		return newOSC52(tmux), nil
+	}
+
+	return nil, errors.New("no clipboard is available, please install wl-copy, xclip or xsel, or set clipboard.backend in configuration")
+}
+
+// Digest returns hash of text which is used to check if clipboard is changed
+func Digest(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// ClearIfUnchanged clears clipboard only if it still has the content of digest
+// Clipboard which cannot be read is always cleared.
+func ClearIfUnchanged(cb Clipboard, digest string) error {
+	content, err := cb.Paste()
+	if err != nil && err != ErrPasteNotSupported {
+		return err
+	}
+
+	if err == nil && Digest(content) != digest {
+		logrus.Debugf("clipboard is changed by other application, skip clearing")
+		return nil
+	}
+
+	return cb.Clear()
+}
+
+// ScheduleClear runs background process of act which clears clipboard after the timeout
+func ScheduleClear(cb Clipboard, text string, after time.Duration) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(executable, constants.ClearClipboardCommand, cb.Name(), Digest(text), strconv.Itoa(int(after.Seconds())))
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	logrus.Debugf("clipboard will be cleared after %s by process %d", after, cmd.Process.Pid)
+
+	return cmd.Process.Release()
+}
@@ -0,0 +1,111 @@
+package clipboard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/internal/clipboardtest"
+)
+
+func TestDetect(t *testing.T) {
+	testData := []struct {
+		env      map[string]string
+		commands []string
+		expected string
+	}{
+		{
+			env:      map[string]string{"SSH_TTY": "/dev/pts/0", "DISPLAY": "localhost:10.0"},
+			commands: []string{"xclip"},
+			expected: constants.XclipClipboard,
+		},
+		{
+			env:      map[string]string{"SSH_TTY": "/dev/pts/0"},
+			commands: []string{"xclip"},
+			expected: constants.OSC52Clipboard,
+		},
+		{
+			env:      map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"},
+			commands: []string{"wl-copy", "xclip"},
+			expected: constants.WlCopyClipboard,
+		},
+		{
+			env:      map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"},
+			commands: []string{"xclip"},
+			expected: constants.XclipClipboard,
+		},
+		{
+			env:      map[string]string{"DISPLAY": ":0"},
+			commands: []string{"xsel"},
+			expected: constants.XselClipboard,
+		},
+		{
+			env:      map[string]string{"TMUX": "/tmp/tmux-1000/default,1,0"},
+			expected: constants.OSC52Clipboard,
+		},
+		{
+			env:      map[string]string{},
+			commands: []string{"xclip"},
+		},
+	}
+
+	for _, td := range testData {
+		lookPath := func(file string) (string, error) {
+			for _, c := range td.commands {
+				if c == file {
+					return "/usr/bin/" + file, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+
+		cb, err := detect(func(key string) string { return td.env[key] }, lookPath)
+		if len(td.expected) == 0 {
+			if err == nil {
+				t.Errorf("%v: expected error, output %s", td.env, cb.Name())
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%v: %s", td.env, err.Error())
+		}
+
+		if cb.Name() != td.expected {
+			t.Errorf("%v: expected %s, output %s", td.env, td.expected, cb.Name())
+		}
+	}
+}
+
+func TestClearIfUnchanged(t *testing.T) {
+	cb := &clipboardtest.Fake{}
+	if err := cb.Copy("token"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ClearIfUnchanged(cb, Digest("other")); err != nil {
+		t.Fatal(err)
+	}
+
+	if cb.Cleared || cb.Content != "token" {
+		t.Errorf("clipboard changed by other application should not be cleared")
+	}
+
+	if err := ClearIfUnchanged(cb, Digest("token")); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cb.Cleared || cb.Content != "" {
+		t.Errorf("clipboard should be cleared")
+	}
+}
+
+func TestOSC52Sequence(t *testing.T) {
+	if seq := (osc52{}).sequence("token"); seq != "\x1b]52;c;dG9rZW4=\x07" {
+		t.Errorf("wrong sequence: %q", seq)
+	}
+
+	if seq := (osc52{tmux: true}).sequence("token"); seq != "\x1bPtmux;\x1b\x1b]52;c;dG9rZW4=\x07\x1b\\" {
+		t.Errorf("wrong sequence for tmux: %q", seq)
+	}
+}
@@ -0,0 +1,82 @@
+package clipboard
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+)
+
+// commandClipboard uses command line tools for clipboard
+type commandClipboard struct {
+	name      string
+	copyArgs  []string
+	pasteArgs []string
+	clearArgs []string
+}
+
+func pbcopy() Clipboard {
+	return commandClipboard{
+		name:      constants.PbcopyClipboard,
+		copyArgs:  []string{"pbcopy"},
+		pasteArgs: []string{"pbpaste"},
+	}
+}
+
+func wlCopy() Clipboard {
+	return commandClipboard{
+		name:      constants.WlCopyClipboard,
+		copyArgs:  []string{"wl-copy"},
+		pasteArgs: []string{"wl-paste", "--no-newline"},
+		clearArgs: []string{"wl-copy", "--clear"},
+	}
+}
+
+func xclip() Clipboard {
+	return commandClipboard{
+		name:      constants.XclipClipboard,
+		copyArgs:  []string{"xclip", "-selection", "clipboard"},
+		pasteArgs: []string{"xclip", "-selection", "clipboard", "-o"},
+	}
+}
+
+func xsel() Clipboard {
+	return commandClipboard{
+		name:      constants.XselClipboard,
+		copyArgs:  []string{"xsel", "--clipboard", "--input"},
+		pasteArgs: []string{"xsel", "--clipboard", "--output"},
+		clearArgs: []string{"xsel", "--clipboard", "--clear"},
+	}
+}
+
+// Name returns the name of backend
+func (c commandClipboard) Name() string {
+	return c.name
+}
+
+// Copy writes text to the standard input of copy command
+func (c commandClipboard) Copy(text string) error {
+	cmd := exec.Command(c.copyArgs[0], c.copyArgs[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+
+	return cmd.Run()
+}
+
+// Paste reads the output of paste command
+func (c commandClipboard) Paste() (string, error) {
+	out, err := exec.Command(c.pasteArgs[0], c.pasteArgs[1:]...).Output()
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	return string(out), nil
+}
+
+// Clear removes the content of clipboard
+func (c commandClipboard) Clear() error {
+	if len(c.clearArgs) == 0 {
+		return c.Copy(constants.EmptyString)
+	}
+
+	return exec.Command(c.clearArgs[0], c.clearArgs[1:]...).Run()
+}
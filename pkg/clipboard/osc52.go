@@ -0,0 +1,55 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+)
+
+// osc52 copies text with OSC 52 escape sequence, which is handled by terminal of user over SSH
+type osc52 struct {
+	tmux bool
+}
+
+func newOSC52(tmux bool) Clipboard {
+	return osc52{tmux: tmux}
+}
+
+// Name returns the name of backend
+func (o osc52) Name() string {
+	return constants.OSC52Clipboard
+}
+
+// Copy writes escape sequence to the terminal
+func (o osc52) Copy(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open terminal for OSC 52: %w", err)
+	}
+	defer tty.Close()
+
+	_, err = tty.WriteString(o.sequence(text))
+	return err
+}
+
+// Paste is not supported, because most terminals do not allow to read clipboard
+func (o osc52) Paste() (string, error) {
+	return constants.EmptyString, ErrPasteNotSupported
+}
+
+// Clear copies empty text
+func (o osc52) Clear() error {
+	return o.Copy(constants.EmptyString)
+}
+
+// sequence returns OSC 52 escape sequence, which is wrapped with passthrough of tmux if needed
+func (o osc52) sequence(text string) string {
+	seq := fmt.Sprintf("\x1b]52;c;%s\x07", base64.StdEncoding.EncodeToString([]byte(text)))
+	if o.tmux {
+		return fmt.Sprintf("\x1bPtmux;\x1b%s\x1b\\", seq)
+	}
+
+	return seq
+}
@@ -1,26 +1,110 @@
 package config
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/service/sts"
 
 	awsact "github.com/DevopsArtFactory/act/pkg/aws"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
 )
 
 // GetAssumeCreds creates a credentials for assuming role.
 func GetAssumeCreds(arn string, sessionName string, duration int) (*sts.Credentials, error) {
+	return GetChainedAssumeCreds(nil, []schema.RoleHop{{RoleArn: arn}}, sessionName, duration)
+}
+
+// GetChainedAssumeCreds creates a credentials by assuming roles in the chain from base credentials
+func GetChainedAssumeCreds(base *sts.Credentials, chain []schema.RoleHop, sessionName string, duration int) (*sts.Credentials, error) {
+	if err := CheckChainDuration(chain, duration); err != nil {
+		return nil, err
+	}
+
+	return AssumeRoleChain(base, chain, sessionName, duration)
+}
+
+// AssumeRoleChain assumes roles in the chain hop by hop, base IAM user is used if base is nil
+func AssumeRoleChain(base *sts.Credentials, chain []schema.RoleHop, sessionName string, duration int) (*sts.Credentials, error) {
 	sess := awsact.GetAwsSession()
-	svc := awsact.GetSTSClientFn(sess, "ap-northeast-2", nil)
-	input := &sts.AssumeRoleInput{
-		RoleArn:         aws.String(arn),
-		RoleSessionName: aws.String(sessionName),
-		DurationSeconds: aws.Int64(int64(duration)),
+	result := base
+	for _, hop := range chain {
+		var creds *credentials.Credentials
+		if result != nil {
+			creds = awsact.NewStaticCredentials(result)
+		}
+
+		svc := awsact.GetSTSClientFn(sess, awsact.GetSTSRegion(hop.RoleArn), creds)
+		input := &sts.AssumeRoleInput{
+			RoleArn:         aws.String(hop.RoleArn),
+			RoleSessionName: aws.String(sessionName),
+			DurationSeconds: aws.Int64(int64(duration)),
+		}
+
+		if len(hop.SessionName) > 0 {
+			input.RoleSessionName = aws.String(hop.SessionName)
+		}
+
+		if len(hop.ExternalID) > 0 {
+			input.ExternalId = aws.String(hop.ExternalID)
+		}
+
+		output, err := svc.AssumeRole(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to assume %s: %w", hop.RoleArn, err)
+		}
+		result = output.Credentials
 	}
 
-	result, err := svc.AssumeRole(input)
-	if err != nil {
-		return nil, err
+	return result, nil
+}
+
+// CheckChainDuration checks if duration exceeds the limit of role chaining
+func CheckChainDuration(chain []schema.RoleHop, duration int) error {
+	if len(chain) > 1 && duration > constants.MaxRoleChainingDuration {
+		return fmt.Errorf("role chaining limits the session duration to %d seconds, but %d seconds is requested. "+
+			"please set `duration` of configuration or `--duration` to %d or less",
+			constants.MaxRoleChainingDuration, duration, constants.MaxRoleChainingDuration)
+	}
+
+	return nil
+}
+
+// GetRoleChain returns roles to assume for target environment, resolving alias if needed
+func GetRoleChain(c *schema.Config, target string) ([]schema.RoleHop, error) {
+	for _, env := range []string{c.Alias[target], target} {
+		if len(env) == 0 {
+			continue
+		}
+
+		if chain, ok := c.RoleChains[env]; ok && len(chain) > 0 {
+			return chain, nil
+		}
+
+		if arn := c.AssumeRoles[env]; len(arn) > 0 {
+			return []schema.RoleHop{{RoleArn: arn}}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s is not registered in the assume list", target)
+}
+
+// GetEnvironments returns all environments which can be assumed
+func GetEnvironments(c *schema.Config) []string {
+	envs := []string{}
+	for env := range c.AssumeRoles {
+		envs = append(envs, env)
+	}
+
+	for env := range c.RoleChains {
+		if _, ok := c.AssumeRoles[env]; !ok {
+			envs = append(envs, env)
+		}
 	}
+	sort.Strings(envs)
 
-	return result.Credentials, nil
+	return envs
 }
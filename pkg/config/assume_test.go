@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestGetRoleChain(t *testing.T) {
+	c := &schema.Config{
+		Alias: map[string]string{
+			"p": "prod",
+			"s": "secure",
+		},
+		AssumeRoles: map[string]string{
+			"prod": "arn:aws:iam::111111111111:role/prod",
+		},
+		RoleChains: map[string][]schema.RoleHop{
+			"secure": {
+				{RoleArn: "arn:aws:iam::222222222222:role/hub", ExternalID: "hub"},
+				{RoleArn: "arn:aws:iam::333333333333:role/secure"},
+			},
+		},
+	}
+
+	testData := []struct {
+		input    string
+		expected int
+		isError  bool
+	}{
+		{input: "p", expected: 1},
+		{input: "prod", expected: 1},
+		{input: "s", expected: 2},
+		{input: "secure", expected: 2},
+		{input: "dev", isError: true},
+	}
+
+	for _, td := range testData {
+		chain, err := GetRoleChain(c, td.input)
+		if (err != nil) != td.isError {
+			t.Errorf("input: %s, unexpected error: %v", td.input, err)
+		}
+
+		if len(chain) != td.expected {
+			t.Errorf("input: %s, expected: %d, output: %d", td.input, td.expected, len(chain))
+		}
+	}
+
+	if err := CheckChainDuration(c.RoleChains["secure"], 7200); err == nil {
+		t.Errorf("role chaining should not allow more than 1 hour")
+	}
+
+	if err := CheckChainDuration([]schema.RoleHop{{RoleArn: c.AssumeRoles["prod"]}}, 7200); err != nil {
+		t.Errorf("single role should allow more than 1 hour: %v", err)
+	}
+}
@@ -66,8 +66,8 @@ func getLocalConfig() ([]schema.Config, error) {
 
 func setDefault(configs []schema.Config) []schema.Config {
 	for i, c := range configs {
-		if c.Duration == 0 {
-			configs[i].Duration = 7200
+		if c.MFASessionDuration == 0 {
+			configs[i].MFASessionDuration = constants.DefaultMFASessionDuration
 		}
 	}
 
@@ -21,12 +21,30 @@ const (
 	// EmptyString is the empty string
 	EmptyString = ""
 
+	// DefaultPartition is the default partition of AWS
+	DefaultPartition = "aws"
+
+	// DefaultMFADeviceName is the default template of MFA device name
+	DefaultMFADeviceName = "{{ .Name }}"
+
 	// DefaultProfile is the default aws profile
 	DefaultProfile = "default"
 
 	// DefaultDuration is the default duration of assume role
 	DefaultDuration = 0
 
+	// DefaultAssumeDuration is the default duration of assume role session
+	DefaultAssumeDuration = 7200
+
+	// MaxRoleChainingDuration is the maximum duration of role chaining session, which is also the default of chained roles
+	MaxRoleChainingDuration = 3600
+
+	// DefaultMFASessionDuration is the default duration of MFA session
+	DefaultMFASessionDuration = 43200
+
+	// MaxMFASessionDuration is the maximum duration of MFA session
+	MaxMFASessionDuration = 129600
+
 	// InfoLogLevel is the info level verbosity
 	InfoLogLevel = logrus.InfoLevel
 
@@ -51,18 +69,216 @@ const (
 	// DefaultKeyType default type of key storage
 	DefaultKeyType = "keychain"
 
+	// FileKeyType is the type of key storage with encrypted file
+	FileKeyType = "file"
+
+	// VaultPassphraseEnv is the environment variable for passphrase of vault file
+	VaultPassphraseEnv = "ACT_VAULT_PASSPHRASE"
+
+	// CredentialProcessVersion is the version of credential_process output format
+	CredentialProcessVersion = 1
+
+	// ShellFormat is the output format for POSIX shell
+	ShellFormat = "sh"
+
+	// FishFormat is the output format for fish shell
+	FishFormat = "fish"
+
+	// PowerShellFormat is the output format for PowerShell
+	PowerShellFormat = "powershell"
+
+	// DotenvFormat is the output format for dotenv file and docker `--env-file`
+	DotenvFormat = "dotenv"
+
+	// JSONFormat is the output format for JSON
+	JSONFormat = "json"
+
+	// GithubActionsFormat is the output format for GitHub Actions workflow
+	GithubActionsFormat = "github-actions"
+
+	// AutoClipboard detects clipboard of current environment
+	AutoClipboard = "auto"
+
+	// PbcopyClipboard is the clipboard of macOS
+	PbcopyClipboard = "pbcopy"
+
+	// WlCopyClipboard is the clipboard of Wayland
+	WlCopyClipboard = "wl-copy"
+
+	// XclipClipboard is the clipboard of X11 with xclip
+	XclipClipboard = "xclip"
+
+	// XselClipboard is the clipboard of X11 with xsel
+	XselClipboard = "xsel"
+
+	// OSC52Clipboard is the clipboard of terminal with OSC 52 escape sequence
+	OSC52Clipboard = "osc52"
+
+	// ClearClipboardCommand is the hidden command which clears clipboard in background
+	ClearClipboardCommand = "clear-clipboard"
+
+	// DefaultLoadtestEnv is the default environment of loadtest
+	DefaultLoadtestEnv = "loadtest"
+
+	// TagSnapshot stores capacity snapshot of autoscaling group in its tag
+	TagSnapshot = "tag"
+
+	// FileSnapshot stores capacity snapshot of autoscaling group in the state file
+	FileSnapshot = "file"
+
+	// CapacitySnapshotTag is the tag key of capacity snapshot
+	CapacitySnapshotTag = "act:loadtest-capacity"
+
+	// LoadtestWaitInterval is the interval of checking loadtest resources
+	LoadtestWaitInterval = 15 * time.Second
+
+	// LoadtestWaitTimeout is the timeout of waiting for loadtest resources
+	LoadtestWaitTimeout = 30 * time.Minute
+
+	// DefaultServeAddress is the default address of local credential server
+	DefaultServeAddress = "127.0.0.1"
+
+	// DefaultServePort is the default port of local credential server
+	DefaultServePort = 9911
+
 	// DefaultMaintenancePriority means the default value of rule priority
 	DefaultMaintenancePriority = 10
+
+	// DefaultBypassPriority is the first priority of bypass rules, which should be higher than maintenance rule
+	DefaultBypassPriority = 1
+
+	// MaxConditionValues is the maximum number of condition values in a listener rule
+	MaxConditionValues = 5
+
+	// DefaultMaintenanceStatusCode is the default status code of maintenance response
+	DefaultMaintenanceStatusCode = 503
+
+	// MaintenanceErrorCode is the code of default maintenance message
+	MaintenanceErrorCode = -99999
+
+	// MaxFixedResponseSize is the maximum size of message body in fixed-response action
+	MaxFixedResponseSize = 1024
+
+	// MaintenanceTag is the tag key of listener rules which are created by act
+	MaintenanceTag = "act:maintenance"
+
+	// MaintenanceRuleType is the tag value of fixed-response rule
+	MaintenanceRuleType = "maintenance"
+
+	// MaintenanceClockInterval is the interval of checking wall clock for the maintenance schedule
+	MaintenanceClockInterval = 10 * time.Second
+
+	// MaxMaintenanceOffRetry is the maximum number of retries when scheduled maintenance fails to be turned off
+	MaxMaintenanceOffRetry = 4
+
+	// MaintenanceOffRetryInterval is the first interval of retry to turn off scheduled maintenance, which is doubled on every retry
+	MaintenanceOffRetryInterval = 10 * time.Second
+
+	// BypassRuleType is the tag value of rule which forwards bypass requests to the original action
+	BypassRuleType = "bypass"
+
+	// MaxRulePriority is the maximum priority value of listener rule
+	MaxRulePriority = 50000
+
+	// CreateRuleChange means the listener rule will be created
+	CreateRuleChange = "create"
+
+	// DeleteRuleChange means the listener rule will be deleted
+	DeleteRuleChange = "delete"
+
+	// AutoWafVersion detects version of WAF from web ACLs
+	AutoWafVersion = "auto"
+
+	// ClassicWafVersion is the version of WAF Classic
+	ClassicWafVersion = "classic"
+
+	// WafV2Version is the version of WAFv2
+	WafV2Version = "v2"
+
+	// RegionalScope is the scope of WAF for regional resources like application load balancer
+	RegionalScope = "REGIONAL"
+
+	// CloudFrontScope is the scope of WAF for CloudFront distribution
+	CloudFrontScope = "CLOUDFRONT"
+
+	// CloudFrontRegion is the region of WAFv2 API for CloudFront scope
+	CloudFrontRegion = "us-east-1"
+
+	// UnknownWafAction is the action of WAF rule which is not supported by act, e.g. action added to API later
+	UnknownWafAction = "UNKNOWN"
+
+	// MaxWafListLimit is the maximum number of items in a WAF list request
+	MaxWafListLimit = 100
+
+	// MaxWafUpdateRetry is the maximum number of retries when change token or lock token of WAF is stale
+	MaxWafUpdateRetry = 3
+
+	// MaxClassicIPSetUpdates is the maximum number of updates in an UpdateIPSet request of WAF Classic
+	MaxClassicIPSetUpdates = 1000
+
+	// MaxIPSetAddresses is the maximum number of addresses in an IP set
+	MaxIPSetAddresses = 10000
+
+	// YAMLFormat is the file format of IP sets in YAML
+	YAMLFormat = "yaml"
+
+	// CSVFormat is the file format of IP sets in CSV
+	CSVFormat = "csv"
+
+	// DefaultWafConcurrency is the default number of WAF requests sent at the same time
+	DefaultWafConcurrency = 5
+
+	// RuleGroupCondition is the condition type of rule group in rule
+	RuleGroupCondition = "RuleGroup"
+
+	// ManagedRuleGroupCondition is the condition type of managed rule group of WAFv2
+	ManagedRuleGroupCondition = "ManagedRuleGroup"
+
+	// AndOperator means all conditions in the statement should match
+	AndOperator = "AND"
+
+	// OrOperator means one of conditions in the statement should match
+	OrOperator = "OR"
+
+	// MaxThrottleRetry is the maximum number of retries when AWS API is throttled
+	MaxThrottleRetry = 5
+
+	// ThrottleRetryInterval is the first interval of retry when AWS API is throttled, which is doubled on every retry
+	ThrottleRetryInterval = time.Second
+
+	// ExactMatch means the query is the same network as the descriptor of IP set
+	ExactMatch = "exact"
+
+	// ContainedMatch means all addresses of the query are contained in the descriptor of IP set
+	ContainedMatch = "contained"
+
+	// OverlapMatch means only part of addresses of the query are contained in the descriptor of IP set
+	OverlapMatch = "overlaps"
+
+	// MaxELBDescribeTags is the maximum number of resources in a DescribeTags request
+	MaxELBDescribeTags = 20
 )
 
 var (
 	AWSConfigDirectoryPath = HomeDir() + "/.aws"
 	AWSCredentialsPath     = AWSConfigDirectoryPath + "/credentials"
 	BaseFilePath           = AWSConfigDirectoryPath + "/config.yaml"
-	BaseSerialNumber       = "arn:aws:iam::748177903968:mfa"
+	DefaultVaultFilePath   = AWSConfigDirectoryPath + "/act-vault"
+	DefaultLoadtestState   = AWSConfigDirectoryPath + "/act-loadtest.json"
+	MaintenanceStatePath   = AWSConfigDirectoryPath + "/act-maintenance.json"
+	WafAuditLogPath        = AWSConfigDirectoryPath + "/act-waf-audit.log"
 
 	DefaultKeyChainPath    = fmt.Sprintf("%s-vault.keychain", ServiceName)
 	DefaultKeyChainAccount = fmt.Sprintf("%s-default", ServiceName)
+
+	// OutputFormats is the list of output formats for credentials
+	OutputFormats = []string{ShellFormat, FishFormat, PowerShellFormat, DotenvFormat, JSONFormat, GithubActionsFormat}
+
+	// WafVersions is the list of WAF versions
+	WafVersions = []string{AutoWafVersion, ClassicWafVersion, WafV2Version}
+
+	// WafScopes is the list of WAF scopes
+	WafScopes = []string{RegionalScope, CloudFrontScope}
 )
 
 // Get Home Directory
@@ -2,6 +2,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 
 	"github.com/DevopsArtFactory/act/pkg/builder"
 	"github.com/DevopsArtFactory/act/pkg/config"
@@ -91,7 +92,13 @@ func createNewExecutor(config *schema.Config) (*Executor, error) {
 }
 
 // alwaysSucceedWhenCancelled makes response true if user canceled
+// Exit error of command run by act is kept so that its exit code is passed through.
 func alwaysSucceedWhenCancelled(ctx context.Context, err error) error {
+	var exitErr *runner.ExitError
+	if errors.As(err, &exitErr) {
+		return err
+	}
+
 	// if the context was cancelled act as if all is well
 	if err != nil && ctx.Err() == context.Canceled {
 		return nil
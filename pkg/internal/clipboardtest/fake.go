@@ -0,0 +1,32 @@
+// Package clipboardtest provides clipboard for tests
+package clipboardtest
+
+// Fake is an in-memory clipboard for tests
+type Fake struct {
+	Content string
+	Cleared bool
+}
+
+// Name returns the name of backend
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+// Copy stores text in memory
+func (f *Fake) Copy(text string) error {
+	f.Content = text
+	f.Cleared = false
+	return nil
+}
+
+// Paste returns text in memory
+func (f *Fake) Paste() (string, error) {
+	return f.Content, nil
+}
+
+// Clear removes text in memory
+func (f *Fake) Clear() error {
+	f.Content = ""
+	f.Cleared = true
+	return nil
+}
@@ -0,0 +1,176 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+// Variable is an environment variable of credentials
+type Variable struct {
+	Name  string
+	Value string
+}
+
+// renderers are functions which write environment variables in each format
+var renderers = map[string]func(out io.Writer, vars []Variable) error{
+	constants.ShellFormat:         renderShell,
+	constants.FishFormat:          renderFish,
+	constants.PowerShellFormat:    renderPowerShell,
+	constants.DotenvFormat:        renderDotenv,
+	constants.GithubActionsFormat: renderGithubActions,
+}
+
+// EnvVariables returns environment variables of credentials
+func EnvVariables(creds *sts.Credentials, region string) []Variable {
+	vars := []Variable{
+		{Name: "AWS_ACCESS_KEY_ID", Value: *creds.AccessKeyId},
+		{Name: "AWS_SECRET_ACCESS_KEY", Value: *creds.SecretAccessKey},
+		{Name: "AWS_SESSION_TOKEN", Value: *creds.SessionToken},
+	}
+
+	if creds.Expiration != nil {
+		vars = append(vars, Variable{Name: "AWS_CREDENTIAL_EXPIRATION", Value: creds.Expiration.UTC().Format(time.RFC3339)})
+	}
+
+	if len(region) > 0 {
+		vars = append(vars,
+			Variable{Name: "AWS_REGION", Value: region},
+			Variable{Name: "AWS_DEFAULT_REGION", Value: region},
+		)
+	}
+
+	return vars
+}
+
+// Render writes credentials in the format
+func Render(out io.Writer, format string, creds *sts.Credentials, region string) error {
+	if format == constants.JSONFormat {
+		return renderJSON(out, creds, region)
+	}
+
+	render, ok := renderers[format]
+	if !ok {
+		return fmt.Errorf("unsupported format: %s, available formats are %s", format, strings.Join(constants.OutputFormats, ", "))
+	}
+
+	return render(out, EnvVariables(creds, region))
+}
+
+// DetectFormat returns the output format for the shell of user
+func DetectFormat() string {
+	return detectFormat(os.Getenv)
+}
+
+// detectFormat finds the shell from environment variables
+func detectFormat(getenv func(string) string) string {
+	if getenv("GITHUB_ACTIONS") == "true" {
+		return constants.GithubActionsFormat
+	}
+
+	switch strings.TrimSuffix(filepath.Base(getenv("SHELL")), ".exe") {
+	case "fish":
+		return constants.FishFormat
+	case "pwsh", "powershell":
+		return constants.PowerShellFormat
+	}
+
+	// PowerShell does not set SHELL, but PSModulePath always exists in it
+	if len(getenv("SHELL")) == 0 && len(getenv("PSModulePath")) > 0 {
+		return constants.PowerShellFormat
+	}
+
+	return constants.ShellFormat
+}
+
+// renderShell writes export statements of POSIX shell
+func renderShell(out io.Writer, vars []Variable) error {
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(out, "export %s=%s\n", v.Name, quote(v.Value, "'", `'\''`)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderFish writes set statements of fish shell
+func renderFish(out io.Writer, vars []Variable) error {
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(out, "set -gx %s %s;\n", v.Name, quote(strings.ReplaceAll(v.Value, `\`, `\\`), "'", `\'`)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderPowerShell writes environment variable assignments of PowerShell
+func renderPowerShell(out io.Writer, vars []Variable) error {
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(out, "$Env:%s = %s\n", v.Name, quote(v.Value, "'", "''")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderDotenv writes variables without quotes, because docker `--env-file` does not remove them
+func renderDotenv(out io.Writer, vars []Variable) error {
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(out, "%s=%s\n", v.Name, v.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderGithubActions writes commands which mask secrets and add variables to $GITHUB_ENV
+// usage: eval "$(act setup [env] --format github-actions)"
+func renderGithubActions(out io.Writer, vars []Variable) error {
+	for _, v := range vars {
+		if v.Name == "AWS_SECRET_ACCESS_KEY" || v.Name == "AWS_SESSION_TOKEN" || v.Name == "AWS_ACCESS_KEY_ID" {
+			if _, err := fmt.Fprintf(out, "echo %s\n", quote(fmt.Sprintf("::add-mask::%s", v.Value), "'", `'\''`)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(out, "echo %s >> \"$GITHUB_ENV\"\n", quote(fmt.Sprintf("%s=%s", v.Name, v.Value), "'", `'\''`)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderJSON writes credentials as a JSON object
+func renderJSON(out io.Writer, creds *sts.Credentials, region string) error {
+	output := schema.CredentialsOutput{
+		AccessKeyID:     *creds.AccessKeyId,
+		SecretAccessKey: *creds.SecretAccessKey,
+		SessionToken:    *creds.SessionToken,
+		Region:          region,
+	}
+
+	if creds.Expiration != nil {
+		output.Expiration = creds.Expiration.UTC().Format(time.RFC3339)
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(output)
+}
+
+// quote wraps value with quote character, escaping the quote character in it
+func quote(value, q, escaped string) string {
+	return q + strings.ReplaceAll(value, q, escaped) + q
+}
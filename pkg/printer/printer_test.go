@@ -0,0 +1,109 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+)
+
+func TestRender(t *testing.T) {
+	creds := &sts.Credentials{
+		AccessKeyId:     aws.String("ASIAEXAMPLE"),
+		SecretAccessKey: aws.String("se'cret"),
+		SessionToken:    aws.String("token"),
+		Expiration:      aws.Time(time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	testData := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: constants.ShellFormat,
+			expected: "export AWS_ACCESS_KEY_ID='ASIAEXAMPLE'\n" +
+				"export AWS_SECRET_ACCESS_KEY='se'\\''cret'\n" +
+				"export AWS_SESSION_TOKEN='token'\n" +
+				"export AWS_CREDENTIAL_EXPIRATION='2020-09-01T12:00:00Z'\n" +
+				"export AWS_REGION='ap-northeast-2'\n" +
+				"export AWS_DEFAULT_REGION='ap-northeast-2'\n",
+		},
+		{
+			format: constants.FishFormat,
+			expected: "set -gx AWS_ACCESS_KEY_ID 'ASIAEXAMPLE';\n" +
+				"set -gx AWS_SECRET_ACCESS_KEY 'se\\'cret';\n" +
+				"set -gx AWS_SESSION_TOKEN 'token';\n" +
+				"set -gx AWS_CREDENTIAL_EXPIRATION '2020-09-01T12:00:00Z';\n" +
+				"set -gx AWS_REGION 'ap-northeast-2';\n" +
+				"set -gx AWS_DEFAULT_REGION 'ap-northeast-2';\n",
+		},
+		{
+			format: constants.PowerShellFormat,
+			expected: "$Env:AWS_ACCESS_KEY_ID = 'ASIAEXAMPLE'\n" +
+				"$Env:AWS_SECRET_ACCESS_KEY = 'se''cret'\n" +
+				"$Env:AWS_SESSION_TOKEN = 'token'\n" +
+				"$Env:AWS_CREDENTIAL_EXPIRATION = '2020-09-01T12:00:00Z'\n" +
+				"$Env:AWS_REGION = 'ap-northeast-2'\n" +
+				"$Env:AWS_DEFAULT_REGION = 'ap-northeast-2'\n",
+		},
+		{
+			format: constants.DotenvFormat,
+			expected: "AWS_ACCESS_KEY_ID=ASIAEXAMPLE\n" +
+				"AWS_SECRET_ACCESS_KEY=se'cret\n" +
+				"AWS_SESSION_TOKEN=token\n" +
+				"AWS_CREDENTIAL_EXPIRATION=2020-09-01T12:00:00Z\n" +
+				"AWS_REGION=ap-northeast-2\n" +
+				"AWS_DEFAULT_REGION=ap-northeast-2\n",
+		},
+		{
+			format: constants.JSONFormat,
+			expected: "{\n" +
+				"  \"AccessKeyId\": \"ASIAEXAMPLE\",\n" +
+				"  \"SecretAccessKey\": \"se'cret\",\n" +
+				"  \"SessionToken\": \"token\",\n" +
+				"  \"Expiration\": \"2020-09-01T12:00:00Z\",\n" +
+				"  \"Region\": \"ap-northeast-2\"\n" +
+				"}\n",
+		},
+	}
+
+	for _, td := range testData {
+		var buf bytes.Buffer
+		if err := Render(&buf, td.format, creds, "ap-northeast-2"); err != nil {
+			t.Fatalf("%s: %s", td.format, err.Error())
+		}
+
+		if buf.String() != td.expected {
+			t.Errorf("%s\nexpected:\n%s\noutput:\n%s", td.format, td.expected, buf.String())
+		}
+	}
+
+	if err := Render(&bytes.Buffer{}, "cmd", creds, "ap-northeast-2"); err == nil {
+		t.Errorf("unsupported format should return error")
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	testData := []struct {
+		env      map[string]string
+		expected string
+	}{
+		{env: map[string]string{"SHELL": "/bin/zsh"}, expected: constants.ShellFormat},
+		{env: map[string]string{"SHELL": "/usr/local/bin/fish"}, expected: constants.FishFormat},
+		{env: map[string]string{"SHELL": "/usr/bin/pwsh"}, expected: constants.PowerShellFormat},
+		{env: map[string]string{"PSModulePath": "/opt/microsoft/powershell/7/Modules"}, expected: constants.PowerShellFormat},
+		{env: map[string]string{"SHELL": "/bin/bash", "GITHUB_ACTIONS": "true"}, expected: constants.GithubActionsFormat},
+		{env: map[string]string{}, expected: constants.ShellFormat},
+	}
+
+	for _, td := range testData {
+		output := detectFormat(func(key string) string { return td.env[key] })
+		if output != td.expected {
+			t.Errorf("%v: expected %s, output %s", td.env, td.expected, output)
+		}
+	}
+}
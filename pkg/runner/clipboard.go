@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/clipboard"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+)
+
+// GetClipboard returns clipboard configured by user, or detected one
+func (r Runner) GetClipboard() (clipboard.Clipboard, error) {
+	if r.Config == nil {
+		return clipboard.New(constants.EmptyString)
+	}
+
+	return clipboard.New(r.Config.Clipboard.Backend)
+}
+
+// CopyToClipboard copies text to clipboard and schedules clearing it if `clear_after` is set
+func (r Runner) CopyToClipboard(text string) error {
+	cb, err := r.GetClipboard()
+	if err != nil {
+		return err
+	}
+
+	return r.copyWithClear(cb, text)
+}
+
+// copyWithClear copies text to the clipboard and schedules clearing it
+func (r Runner) copyWithClear(cb clipboard.Clipboard, text string) error {
+	if err := cb.Copy(text); err != nil {
+		return err
+	}
+	logrus.Debugf("text is copied with %s", cb.Name())
+
+	if r.Config == nil || r.Config.Clipboard.ClearAfter <= 0 {
+		return nil
+	}
+
+	return clipboard.ScheduleClear(cb, text, time.Duration(r.Config.Clipboard.ClearAfter)*time.Second)
+}
+
+// ClearClipboard waits for the timeout and clears clipboard if it is not changed
+// args: [backend] [digest] [seconds]
+func (r Runner) ClearClipboard(ctx context.Context, args []string) error {
+	if len(args) != 3 {
+		return errors.New("usage: act clear-clipboard [backend] [digest] [seconds]")
+	}
+
+	seconds, err := strconv.Atoi(args[2])
+	if err != nil {
+		return err
+	}
+
+	cb, err := clipboard.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(time.Duration(seconds) * time.Second):
+	}
+
+	return clipboard.ClearIfUnchanged(cb, args[1])
+}
@@ -0,0 +1,21 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/DevopsArtFactory/act/pkg/internal/clipboardtest"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestCopyWithClear(t *testing.T) {
+	cb := &clipboardtest.Fake{}
+	r := Runner{Config: &schema.Config{}}
+
+	if err := r.copyWithClear(cb, "token"); err != nil {
+		t.Fatal(err)
+	}
+
+	if cb.Content != "token" {
+		t.Errorf("expected token, output %s", cb.Content)
+	}
+}
@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/viper"
+
+	"github.com/DevopsArtFactory/act/pkg/aws"
+	"github.com/DevopsArtFactory/act/pkg/color"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+// Console creates sign-in URL of AWS console with assume role credentials
+func (r Runner) Console(out io.Writer, args []string) error {
+	if r.Config == nil {
+		return errors.New(constants.ConfigErrorMsg)
+	}
+
+	target, chain, err := r.ResolveRoleChain(args)
+	if err != nil {
+		return err
+	}
+
+	assumeCreds, err := r.GetAssumeCreds(chain)
+	if err != nil {
+		return err
+	}
+
+	endpoint, console := aws.GetConsoleEndpoints(chain[len(chain)-1].RoleArn)
+	if len(r.Config.FederationEndpoint) > 0 {
+		endpoint = r.Config.FederationEndpoint
+	}
+
+	token, err := aws.GetSigninToken(endpoint, assumeCreds)
+	if err != nil {
+		return err
+	}
+
+	loginURL := aws.BuildConsoleLoginURL(endpoint, token, aws.BuildConsoleDestination(console, r.Flag.Service, r.GetRegion()))
+
+	switch {
+	case r.Flag.Open:
+		if err := tools.OpenBrowser(loginURL); err != nil {
+			return err
+		}
+		color.Blue.Fprintf(out, "Console of %s is opened in the browser.", target)
+	case viper.GetBool("raw-output"):
+		fmt.Println(loginURL)
+	default:
+		if err := r.CopyToClipboard(loginURL); err != nil {
+			return fmt.Errorf("failed to copy sign-in URL, please use --raw-output or --open: %w", err)
+		}
+		color.Blue.Fprintf(out, "Sign-in URL of %s is copied to clipboard, please paste it to the browser.", target)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+// CredentialProcess prints credentials in the format of AWS CLI `credential_process`
+func (r Runner) CredentialProcess(out io.Writer, args []string) error {
+	if r.Config == nil {
+		return errors.New(constants.ConfigErrorMsg)
+	}
+
+	if len(args) != 1 {
+		return errors.New("usage: act credential-process [env]")
+	}
+
+	_, chain, err := r.ResolveRoleChain(args)
+	if err != nil {
+		return err
+	}
+
+	creds, err := r.GetAssumeCreds(chain)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(schema.CredentialProcessOutput{
+		Version:         constants.CredentialProcessVersion,
+		AccessKeyID:     *creds.AccessKeyId,
+		SecretAccessKey: *creds.SecretAccessKey,
+		SessionToken:    *creds.SessionToken,
+		Expiration:      creds.Expiration.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,143 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/args"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/printer"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+// credentialEnvKeys are environment variables which are overridden by exec command
+var credentialEnvKeys = []string{
+	"AWS_ACCESS_KEY_ID",
+	"AWS_SECRET_ACCESS_KEY",
+	"AWS_SESSION_TOKEN",
+	"AWS_SECURITY_TOKEN",
+	"AWS_CREDENTIAL_EXPIRATION",
+	"AWS_PROFILE",
+	"AWS_REGION",
+	"AWS_DEFAULT_REGION",
+}
+
+// forwardedSignals are signals which are passed to the command run by exec
+var forwardedSignals = []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}
+
+// Exec runs command with assume role credentials of target environment
+func (r Runner) Exec(ctx context.Context, arguments []string) error {
+	if r.Config == nil {
+		return errors.New(constants.ConfigErrorMsg)
+	}
+
+	a, err := args.Parse(arguments)
+	if err != nil {
+		return err
+	}
+
+	_, chain, err := r.ResolveRoleChain([]string{a.Profile})
+	if err != nil {
+		return err
+	}
+
+	assumeCreds, err := r.GetAssumeCreds(chain)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(a.Command, a.Args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = buildExecEnv(os.Environ(), assumeCreds, r.GetRegion())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, forwardedSignals...)
+	defer signal.Stop(signals)
+
+	logrus.Debugf("run command with assume role of %s: %s", a.Profile, a.Command)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	// pass signals caught by act to the child process
+	// Signal which cancels context is also delivered to signals, so it is forwarded only once.
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				forwardSignal(cmd.Process, sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	if err := cmd.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &ExitError{ExitError: exitErr}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ExitError is the exit of command run by exec, whose exit code is passed through by act
+type ExitError struct {
+	*exec.ExitError
+}
+
+// Code returns exit code of the command in the same way as shell
+func (e *ExitError) Code() int {
+	return ExitCode(e.ExitError)
+}
+
+// forwardSignal sends signal to process, which is killed if the signal cannot be sent
+func forwardSignal(process *os.Process, sig os.Signal) {
+	logrus.Debugf("forward signal to the command: %s", sig)
+	if err := process.Signal(sig); err != nil {
+		process.Kill()
+	}
+}
+
+// ExitCode returns exit code of command in the same way as shell, which is 128+signal if the command is killed by signal
+func ExitCode(exitErr *exec.ExitError) int {
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+
+	return exitErr.ExitCode()
+}
+
+// buildExecEnv replaces AWS credential environment variables with assume role credentials
+func buildExecEnv(environ []string, creds *sts.Credentials, region string) []string {
+	var env []string
+	for _, e := range environ {
+		key := strings.SplitN(e, "=", 2)[0]
+		if tools.IsStringInArray(key, credentialEnvKeys) {
+			continue
+		}
+		env = append(env, e)
+	}
+
+	for _, v := range printer.EnvVariables(creds, region) {
+		env = append(env, fmt.Sprintf("%s=%s", v.Name, v.Value))
+	}
+
+	return env
+}
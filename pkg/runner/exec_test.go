@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"os/exec"
+	"runtime"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+func TestBuildExecEnv(t *testing.T) {
+	creds := &sts.Credentials{
+		AccessKeyId:     aws.String("ASIAEXAMPLE"),
+		SecretAccessKey: aws.String("secret"),
+		SessionToken:    aws.String("token"),
+	}
+
+	env := buildExecEnv([]string{
+		"HOME=/home/act",
+		"AWS_ACCESS_KEY_ID=AKIAOLD",
+		"AWS_PROFILE=default",
+	}, creds, "ap-northeast-2")
+
+	expected := []string{
+		"HOME=/home/act",
+		"AWS_ACCESS_KEY_ID=ASIAEXAMPLE",
+		"AWS_SECRET_ACCESS_KEY=secret",
+		"AWS_SESSION_TOKEN=token",
+		"AWS_REGION=ap-northeast-2",
+		"AWS_DEFAULT_REGION=ap-northeast-2",
+	}
+
+	if len(env) != len(expected) {
+		t.Fatalf("expected: %v, output: %v", expected, env)
+	}
+
+	for _, e := range expected {
+		if !tools.IsStringInArray(e, env) {
+			t.Errorf("%s does not exist in environment: %v", e, env)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signal is not supported on windows")
+	}
+
+	testData := []struct {
+		script   string
+		expected int
+	}{
+		{script: "exit 3", expected: 3},
+		{script: "kill -TERM $$", expected: 143},
+		{script: "kill -INT $$", expected: 130},
+	}
+
+	for _, td := range testData {
+		err := exec.Command("sh", "-c", td.script).Run()
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			t.Fatalf("expected exit error: %v", err)
+		}
+
+		if code := (&ExitError{ExitError: exitErr}).Code(); code != td.expected {
+			t.Errorf("expected: %d, output: %d", td.expected, code)
+		}
+	}
+}
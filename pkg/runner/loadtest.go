@@ -0,0 +1,299 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/aws"
+	"github.com/DevopsArtFactory/act/pkg/color"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+// StartLoadtest starts RDS clusters and autoscaling groups of loadtest environment
+func (r Runner) StartLoadtest(ctx context.Context, out io.Writer, args []string) error {
+	client, asgNames, err := r.prepareLoadtest(args)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range r.Config.Loadtest.RDS {
+		status, err := client.StartLoadtestRDS(id)
+		if err != nil {
+			if !isInvalidClusterState(err) {
+				return err
+			}
+			logrus.Warnf("skip starting %s: %s", id, err.Error())
+			continue
+		}
+		logrus.Infof("start rds cluster %s: %s", id, *status)
+	}
+
+	store, err := newCapacityStore(client, r.Config.Loadtest.Snapshot, r.Config.Loadtest.StateFile)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range asgNames {
+		group, err := client.DescribeASG(name)
+		if err != nil {
+			return err
+		}
+
+		capacity, err := r.getStartCapacity(store, group)
+		if err != nil {
+			return err
+		}
+
+		if err := client.StartLoadtestASG(group, capacity); err != nil {
+			return err
+		}
+		logrus.Infof("start autoscaling group %s: min %d, desired %d, max %d", *name, capacity.MinSize, capacity.DesiredCapacity, capacity.MaxSize)
+	}
+
+	return r.waitLoadtest(ctx, out, client, asgNames, true)
+}
+
+// StopLoadtest stops RDS clusters and autoscaling groups of loadtest environment
+func (r Runner) StopLoadtest(ctx context.Context, out io.Writer, args []string) error {
+	client, asgNames, err := r.prepareLoadtest(args)
+	if err != nil {
+		return err
+	}
+
+	store, err := newCapacityStore(client, r.Config.Loadtest.Snapshot, r.Config.Loadtest.StateFile)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range asgNames {
+		group, err := client.DescribeASG(name)
+		if err != nil {
+			return err
+		}
+
+		// snapshot of group which is already stopped should not be overwritten with zero
+		capacity := aws.GetASGCapacity(group)
+		if isZeroCapacity(capacity) {
+			logrus.Infof("autoscaling group %s is already stopped", *name)
+			continue
+		}
+
+		if err := store.Set(group, capacity); err != nil {
+			return err
+		}
+		logrus.Debugf("capacity snapshot of %s is saved: %+v", *name, capacity)
+
+		if err := client.StopLoadtestASG(name); err != nil {
+			return err
+		}
+		logrus.Infof("stop autoscaling group %s", *name)
+	}
+
+	for _, id := range r.Config.Loadtest.RDS {
+		status, err := client.StopLoadtestRDS(id)
+		if err != nil {
+			if !isInvalidClusterState(err) {
+				return err
+			}
+			logrus.Warnf("skip stopping %s: %s", id, err.Error())
+			continue
+		}
+		logrus.Infof("stop rds cluster %s: %s", id, *status)
+	}
+
+	return r.waitLoadtest(ctx, out, client, asgNames, false)
+}
+
+// PrintLoadtestStatus prints status of RDS clusters and autoscaling groups of loadtest environment
+func (r Runner) PrintLoadtestStatus(out io.Writer, args []string) error {
+	client, asgNames, err := r.prepareLoadtest(args)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := getLoadtestStatuses(client, r.Config.Loadtest.RDS, asgNames, true)
+	if err != nil {
+		return err
+	}
+
+	return printLoadtestStatuses(out, statuses)
+}
+
+// getStartCapacity returns capacity to restore, override in configuration has priority over snapshot
+func (r Runner) getStartCapacity(store capacityStore, group *autoscaling.Group) (schema.ASGCapacity, error) {
+	if capacity, ok := getCapacityOverride(r.Config.Loadtest.Overrides, *group.AutoScalingGroupName); ok {
+		return *capacity, nil
+	}
+
+	capacity, err := store.Get(group)
+	if err != nil {
+		return schema.ASGCapacity{}, err
+	}
+
+	if capacity != nil {
+		return *capacity, nil
+	}
+
+	current := aws.GetASGCapacity(group)
+	if !isZeroCapacity(current) {
+		return current, nil
+	}
+
+	logrus.Warnf("no capacity snapshot of %s exists, set capacity to 1", *group.AutoScalingGroupName)
+	return schema.ASGCapacity{MinSize: 1, DesiredCapacity: 1, MaxSize: 1}, nil
+}
+
+// prepareLoadtest creates client of loadtest environment and finds autoscaling groups
+func (r Runner) prepareLoadtest(args []string) (aws.Client, []*string, error) {
+	if r.Config == nil {
+		return aws.Client{}, nil, errors.New(constants.ConfigErrorMsg)
+	}
+
+	if len(r.Config.Loadtest.RDS) == 0 && len(r.Config.Loadtest.ASG) == 0 {
+		return aws.Client{}, nil, errors.New("no rds or asg is registered in loadtest configuration")
+	}
+
+	env := r.Config.Loadtest.Env
+	if len(args) > 0 {
+		env = args[0]
+	}
+
+	if len(env) == 0 {
+		env = constants.DefaultLoadtestEnv
+	}
+
+	_, client, err := r.NewAssumeClient([]string{env})
+	if err != nil {
+		return aws.Client{}, nil, err
+	}
+
+	var asgNames []*string
+	found := map[string]bool{}
+	for _, pattern := range r.Config.Loadtest.ASG {
+		names, err := client.GetExactASGNames(pattern)
+		if err != nil {
+			return aws.Client{}, nil, err
+		}
+
+		if len(names) == 0 {
+			logrus.Warnf("no autoscaling group matches %s", pattern)
+		}
+
+		for _, name := range names {
+			if !found[*name] {
+				found[*name] = true
+				asgNames = append(asgNames, name)
+			}
+		}
+	}
+
+	return client, asgNames, nil
+}
+
+// waitLoadtest prints status, and waits until all resources are ready if --wait is set
+func (r Runner) waitLoadtest(ctx context.Context, out io.Writer, client aws.Client, asgNames []*string, started bool) error {
+	timeout := time.After(constants.LoadtestWaitTimeout)
+	for {
+		statuses, err := getLoadtestStatuses(client, r.Config.Loadtest.RDS, asgNames, started)
+		if err != nil {
+			return err
+		}
+
+		if !r.Flag.Wait {
+			return printLoadtestStatuses(out, statuses)
+		}
+
+		if isLoadtestReady(statuses) {
+			if err := printLoadtestStatuses(out, statuses); err != nil {
+				return err
+			}
+			color.Green.Fprintln(out, "all loadtest resources are ready")
+			return nil
+		}
+
+		logrus.Infof("waiting for loadtest resources to be ready")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			printLoadtestStatuses(out, statuses)
+			return fmt.Errorf("loadtest resources are not ready in %s", constants.LoadtestWaitTimeout)
+		case <-time.After(constants.LoadtestWaitInterval):
+		}
+	}
+}
+
+// getLoadtestStatuses returns status of loadtest resources, ready means resources are started or stopped as expected
+func getLoadtestStatuses(client aws.Client, clusters []string, asgNames []*string, started bool) ([]schema.LoadtestStatus, error) {
+	var statuses []schema.LoadtestStatus
+	for _, id := range clusters {
+		status, err := client.GetLoadtestRDSStatus(id)
+		if err != nil {
+			return nil, err
+		}
+
+		expected := "stopped"
+		if started {
+			expected = "available"
+		}
+
+		statuses = append(statuses, schema.LoadtestStatus{
+			Type:   "rds",
+			Name:   id,
+			Status: *status,
+			Ready:  *status == expected,
+		})
+	}
+
+	for _, name := range asgNames {
+		status, err := client.GetLoadtestASGStatus(name, started)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// isLoadtestReady checks if all resources are ready
+func isLoadtestReady(statuses []schema.LoadtestStatus) bool {
+	for _, status := range statuses {
+		if !status.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
+// printLoadtestStatuses prints status table of loadtest resources
+func printLoadtestStatuses(out io.Writer, statuses []schema.LoadtestStatus) error {
+	w := tabwriter.NewWriter(out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "TYPE\tNAME\tSTATUS\tREADY")
+	for _, status := range statuses {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", status.Type, status.Name, status.Status, status.Ready)
+	}
+
+	return w.Flush()
+}
+
+// isInvalidClusterState checks if cluster is already started or stopped
+func isInvalidClusterState(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == rds.ErrCodeInvalidDBClusterStateFault
+	}
+
+	return false
+}
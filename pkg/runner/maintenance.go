@@ -0,0 +1,306 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/aws"
+	"github.com/DevopsArtFactory/act/pkg/color"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+// maintenanceListener is a listener of load balancer in maintenance configuration
+type maintenanceListener struct {
+	client          aws.Client
+	loadbalancerArn string
+	listener        *elbv2.Listener
+	rules           []*elbv2.Rule
+	tagged          []aws.TaggedRule
+}
+
+// listenerPlan is the plan of rule changes in a listener
+type listenerPlan struct {
+	client      aws.Client
+	listenerArn string
+	plan        aws.RulePlan
+}
+
+// inMaintenance checks if listener has maintenance rule
+func (l maintenanceListener) inMaintenance() bool {
+	for _, rule := range l.tagged {
+		if rule.Type == constants.MaintenanceRuleType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MaintenanceOn creates maintenance rule in all listeners of load balancers
+func (r Runner) MaintenanceOn(out io.Writer, args []string) error {
+	window, err := r.getMaintenanceWindow(time.Now())
+	if err != nil {
+		return err
+	}
+
+	return r.turnOnMaintenance(out, args, window)
+}
+
+// turnOnMaintenance creates maintenance rules with the responses rendered for the window
+func (r Runner) turnOnMaintenance(out io.Writer, args []string, window schema.MaintenanceWindow) error {
+	// responses are validated before calling any API
+	responses, err := r.renderMaintenanceResponses(window)
+	if err != nil {
+		return err
+	}
+
+	listeners, err := r.getMaintenanceListeners(args)
+	if err != nil {
+		return err
+	}
+
+	var plans []listenerPlan
+	var conflicts []string
+	for _, l := range listeners {
+		if l.inMaintenance() {
+			logrus.Infof("listener is already in maintenance: %s", *l.listener.ListenerArn)
+			continue
+		}
+
+		// bypass rules left by failed command are recreated
+		plan, err := aws.PlanMaintenanceOn(l.listener, l.rules, l.tagged, r.Config.Maintenance.Bypass, responses[l.loadbalancerArn])
+		if err != nil {
+			conflicts = append(conflicts, err.Error())
+			continue
+		}
+		plans = append(plans, listenerPlan{client: l.client, listenerArn: *l.listener.ListenerArn, plan: plan})
+	}
+
+	if len(conflicts) > 0 {
+		color.Red.Fprintln(out, "maintenance rules cannot be created in listeners below")
+		for _, conflict := range conflicts {
+			fmt.Fprintf(out, "  %s\n", conflict)
+		}
+		return errors.New("priority of maintenance rules conflicts with existing rules")
+	}
+
+	if len(plans) == 0 {
+		color.Green.Fprintln(out, "all listeners are already in maintenance")
+		return nil
+	}
+
+	printRulePlans(out, plans)
+
+	if err := r.confirm("Do you want to turn on maintenance? [y/n]"); err != nil {
+		return err
+	}
+
+	if err := applyRulePlans(out, plans, fmt.Sprintf("run `%s` to turn off maintenance of changed listeners", maintenanceOffCommand(args))); err != nil {
+		return err
+	}
+
+	color.Green.Fprintln(out, "maintenance is turned on")
+
+	return nil
+}
+
+// MaintenanceOff removes maintenance rules which are created by act
+func (r Runner) MaintenanceOff(out io.Writer, args []string) error {
+	listeners, err := r.getMaintenanceListeners(args)
+	if err != nil {
+		return err
+	}
+
+	var plans []listenerPlan
+	for _, l := range listeners {
+		if len(l.tagged) > 0 {
+			plans = append(plans, listenerPlan{client: l.client, listenerArn: *l.listener.ListenerArn, plan: aws.PlanMaintenanceOff(l.listener, l.tagged)})
+		}
+	}
+
+	if len(plans) == 0 {
+		color.Green.Fprintln(out, "no listener is in maintenance")
+		return nil
+	}
+
+	printRulePlans(out, plans)
+
+	if err := r.confirm("Do you want to turn off maintenance? [y/n]"); err != nil {
+		return err
+	}
+
+	if err := applyRulePlans(out, plans, fmt.Sprintf("run `%s` again to turn off maintenance of failed listeners", maintenanceOffCommand(args))); err != nil {
+		return err
+	}
+
+	color.Green.Fprintln(out, "maintenance is turned off")
+
+	return nil
+}
+
+// PrintMaintenanceStatus prints listeners which are in maintenance
+func (r Runner) PrintMaintenanceStatus(out io.Writer, args []string) error {
+	listeners, err := r.getMaintenanceListeners(args)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "LOAD BALANCER\tLISTENER\tMAINTENANCE\tRULES")
+	for _, l := range listeners {
+		fmt.Fprintf(w, "%s\t%s\t%t\t%d\n", aws.GetLoadBalancerName(l.loadbalancerArn), aws.DescribeListener(l.listener), l.inMaintenance(), len(l.tagged))
+	}
+
+	return w.Flush()
+}
+
+// getMaintenanceListeners returns HTTP listeners of load balancers with rules created by act
+func (r Runner) getMaintenanceListeners(args []string) ([]maintenanceListener, error) {
+	if r.Config == nil {
+		return nil, errors.New(constants.ConfigErrorMsg)
+	}
+
+	if len(r.Config.Maintenance.Arns) == 0 {
+		return nil, errors.New("no load balancer is registered in maintenance configuration")
+	}
+
+	creds, err := r.getMaintenanceCredentials(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var listeners []maintenanceListener
+	for _, lb := range r.Config.Maintenance.Arns {
+		parsed, err := arn.Parse(lb.LoadbalancerArn)
+		if err != nil {
+			return nil, fmt.Errorf("wrong load balancer arn %s: %s", lb.LoadbalancerArn, err.Error())
+		}
+
+		client := aws.NewClient(aws.GetAwsSession(), parsed.Region, creds)
+		result, err := client.DescribeListeners(lb.LoadbalancerArn)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, listener := range result {
+			// fixed-response is only supported by application load balancer
+			if !tools.IsStringInArray(*listener.Protocol, []string{elbv2.ProtocolEnumHttp, elbv2.ProtocolEnumHttps}) {
+				continue
+			}
+
+			rules, err := client.DescribeRules(listener.ListenerArn)
+			if err != nil {
+				return nil, err
+			}
+
+			tagged, err := client.GetTaggedRules(rules)
+			if err != nil {
+				return nil, err
+			}
+
+			listeners = append(listeners, maintenanceListener{
+				client:          client,
+				loadbalancerArn: lb.LoadbalancerArn,
+				listener:        listener,
+				rules:           rules,
+				tagged:          tagged,
+			})
+		}
+	}
+
+	return listeners, nil
+}
+
+// getMaintenanceCredentials returns credentials of assume role if environment is given
+func (r Runner) getMaintenanceCredentials(args []string) (*credentials.Credentials, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	_, chain, err := r.ResolveRoleChain(args)
+	if err != nil {
+		return nil, err
+	}
+
+	assumeCreds, err := r.GetAssumeCreds(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	return aws.NewStaticCredentials(assumeCreds), nil
+}
+
+// printRulePlans prints diff of rules which will be created or deleted
+func printRulePlans(out io.Writer, plans []listenerPlan) {
+	creates, deletes := 0, 0
+	color.Yellow.Fprintln(out, "listener rules below will be changed")
+	for _, p := range plans {
+		for _, change := range p.plan {
+			if change.Change == constants.DeleteRuleChange {
+				color.Red.Fprintf(out, "  %s\n", change.String())
+			} else {
+				color.Green.Fprintf(out, "  %s\n", change.String())
+			}
+		}
+		creates += p.plan.Creates()
+		deletes += p.plan.Deletes()
+	}
+	fmt.Fprintf(out, "Plan: %d to add, %d to delete.\n", creates, deletes)
+}
+
+// applyRulePlans applies rule changes of each listener, and prints which listeners are changed if any listener fails
+// Listeners are independent, so failure of a listener does not stop changes of other listeners.
+func applyRulePlans(out io.Writer, plans []listenerPlan, recovery string) error {
+	var changed, failed []string
+	for _, p := range plans {
+		if err := p.client.ApplyRulePlan(p.plan); err != nil {
+			logrus.Errorf("failed to change rules of listener %s: %s", p.listenerArn, err.Error())
+			failed = append(failed, p.listenerArn)
+			continue
+		}
+		changed = append(changed, p.listenerArn)
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	if len(changed) > 0 {
+		color.Yellow.Fprintln(out, "listeners below are changed")
+		for _, arn := range changed {
+			fmt.Fprintf(out, "  %s\n", arn)
+		}
+	}
+
+	color.Red.Fprintln(out, "listeners below are failed, and rules may be partially changed")
+	for _, arn := range failed {
+		fmt.Fprintf(out, "  %s\n", arn)
+	}
+
+	return fmt.Errorf("failed to change %d of %d listeners, %s", len(failed), len(plans), recovery)
+}
+
+// maintenanceOffCommand returns command which turns off maintenance of environment
+func maintenanceOffCommand(args []string) string {
+	return strings.TrimSpace(fmt.Sprintf("act maintenance off %s", strings.Join(args, " ")))
+}
+
+// confirm asks user to continue unless --yes is set
+func (r Runner) confirm(msg string) error {
+	if r.Flag.Yes {
+		return nil
+	}
+
+	return tools.AskContinue(msg)
+}
@@ -0,0 +1,179 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/DevopsArtFactory/act/pkg/aws"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+// fixedResponseContentTypes are content types which are supported by fixed-response action
+var fixedResponseContentTypes = []string{
+	"text/plain",
+	"text/css",
+	"text/html",
+	"application/javascript",
+	"application/json",
+}
+
+// getMaintenanceWindow returns template data of maintenance which starts at the time and ends at --end
+func (r Runner) getMaintenanceWindow(start time.Time) (schema.MaintenanceWindow, error) {
+	window := schema.MaintenanceWindow{
+		Message:   r.Config.Maintenance.Message,
+		Start:     start,
+		Timestamp: start.UnixNano() / int64(time.Millisecond),
+	}
+
+	if len(r.Flag.End) > 0 {
+		end, err := tools.ParseTime(r.Flag.End)
+		if err != nil {
+			return window, err
+		}
+		window.End = end
+	}
+
+	return window, nil
+}
+
+// renderMaintenanceResponses renders responses of all load balancers in configuration
+func (r Runner) renderMaintenanceResponses(window schema.MaintenanceWindow) (map[string]schema.MaintenanceResponse, error) {
+	responses := map[string]schema.MaintenanceResponse{}
+	for _, target := range r.Config.Maintenance.Arns {
+		response, err := renderMaintenanceResponse(target, r.Config.Maintenance.Response, window)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance response of %s: %s", target.LoadbalancerArn, err.Error())
+		}
+
+		if _, err := aws.BuildBypassConditions(r.Config.Maintenance.Bypass, response.Hosts, response.Paths); err != nil {
+			return nil, fmt.Errorf("invalid bypass of %s: %s", target.LoadbalancerArn, err.Error())
+		}
+
+		responses[target.LoadbalancerArn] = response
+	}
+
+	return responses, nil
+}
+
+// renderMaintenanceResponse renders response of load balancer, settings of load balancer have priority over default
+func renderMaintenanceResponse(target schema.MaintenanceTarget, defaults schema.MaintenanceResponseConfig, window schema.MaintenanceWindow) (schema.MaintenanceResponse, error) {
+	config := mergeResponseConfig(target.Response, defaults)
+
+	body, err := renderMaintenanceBody(config, window)
+	if err != nil {
+		return schema.MaintenanceResponse{}, err
+	}
+
+	response := schema.MaintenanceResponse{
+		ContentType: config.ContentType,
+		StatusCode:  strconv.Itoa(config.StatusCode),
+		Body:        body,
+		Hosts:       target.Hosts,
+		Paths:       target.Paths,
+	}
+
+	return response, validateMaintenanceResponse(response)
+}
+
+// mergeResponseConfig fills empty settings with default
+func mergeResponseConfig(config, defaults schema.MaintenanceResponseConfig) schema.MaintenanceResponseConfig {
+	if len(config.Template) == 0 && len(config.TemplateFile) == 0 {
+		config.Template = defaults.Template
+		config.TemplateFile = defaults.TemplateFile
+	}
+
+	if len(config.ContentType) == 0 {
+		config.ContentType = defaults.ContentType
+	}
+
+	if config.StatusCode == 0 {
+		config.StatusCode = defaults.StatusCode
+	}
+
+	if config.StatusCode == 0 {
+		config.StatusCode = constants.DefaultMaintenanceStatusCode
+	}
+
+	if len(config.ContentType) == 0 {
+		config.ContentType = "text/plain"
+		if len(config.Template) == 0 && len(config.TemplateFile) == 0 {
+			config.ContentType = "application/json"
+		}
+	}
+
+	return config
+}
+
+// renderMaintenanceBody renders body with template, or JSON message if no template is set
+func renderMaintenanceBody(config schema.MaintenanceResponseConfig, window schema.MaintenanceWindow) (string, error) {
+	text := config.Template
+	if len(config.TemplateFile) > 0 {
+		b, err := ioutil.ReadFile(config.TemplateFile)
+		if err != nil {
+			return constants.EmptyString, err
+		}
+		text = string(b)
+	}
+
+	if len(text) == 0 {
+		b, err := json.Marshal(schema.MaintenanceMessage{
+			Timestamp: window.Timestamp,
+			Code:      constants.MaintenanceErrorCode,
+			Message:   window.Message,
+		})
+		return string(b), err
+	}
+
+	funcMap := template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}
+
+	t, err := template.New("maintenance").Funcs(funcMap).Parse(text)
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, window); err != nil {
+		return constants.EmptyString, err
+	}
+
+	return buf.String(), nil
+}
+
+// validateMaintenanceResponse checks limits of fixed-response action and rule conditions
+func validateMaintenanceResponse(response schema.MaintenanceResponse) error {
+	if len(response.Body) > constants.MaxFixedResponseSize {
+		return fmt.Errorf("message body is %d bytes, but fixed-response cannot be more than %d bytes", len(response.Body), constants.MaxFixedResponseSize)
+	}
+
+	if !tools.IsStringInArray(response.ContentType, fixedResponseContentTypes) {
+		return fmt.Errorf("content type is not supported by fixed-response: %s", response.ContentType)
+	}
+
+	if code, _ := strconv.Atoi(response.StatusCode); code < 200 || code > 599 || (code >= 300 && code < 400) {
+		return fmt.Errorf("status code of fixed-response should be 2XX, 4XX or 5XX: %s", response.StatusCode)
+	}
+
+	// path-pattern `/*` is added if paths are empty
+	values := len(response.Hosts) + len(response.Paths)
+	if len(response.Paths) == 0 {
+		values++
+	}
+
+	if values > constants.MaxConditionValues {
+		return fmt.Errorf("hosts and paths cannot have more than %d values in a rule", constants.MaxConditionValues)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package runner
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestRenderMaintenanceResponse(t *testing.T) {
+	window := schema.MaintenanceWindow{
+		Message:   "under maintenance",
+		Start:     time.Date(2020, 9, 1, 9, 0, 0, 0, time.UTC),
+		End:       time.Date(2020, 9, 1, 11, 0, 0, 0, time.UTC),
+		Timestamp: 1598950800000,
+	}
+
+	testData := []struct {
+		target   schema.MaintenanceTarget
+		defaults schema.MaintenanceResponseConfig
+		expected schema.MaintenanceResponse
+		err      bool
+	}{
+		{
+			expected: schema.MaintenanceResponse{
+				ContentType: "application/json",
+				StatusCode:  "503",
+				Body:        `{"timestamp":1598950800000,"code":-99999,"message":"under maintenance"}`,
+			},
+		},
+		{
+			target: schema.MaintenanceTarget{
+				Response: schema.MaintenanceResponseConfig{
+					ContentType: "text/html",
+					Template:    `<p>{{ .Message }} until {{ .End.Format "15:04" }}</p>`,
+				},
+				Hosts: []string{"api.example.com"},
+			},
+			defaults: schema.MaintenanceResponseConfig{StatusCode: 502, Template: "default"},
+			expected: schema.MaintenanceResponse{
+				ContentType: "text/html",
+				StatusCode:  "502",
+				Body:        "<p>under maintenance until 11:00</p>",
+				Hosts:       []string{"api.example.com"},
+			},
+		},
+		{
+			target: schema.MaintenanceTarget{
+				Response: schema.MaintenanceResponseConfig{Template: strings.Repeat("a", 1025)},
+			},
+			err: true,
+		},
+		{
+			target: schema.MaintenanceTarget{
+				Response: schema.MaintenanceResponseConfig{StatusCode: 302},
+			},
+			err: true,
+		},
+	}
+
+	for i, td := range testData {
+		output, err := renderMaintenanceResponse(td.target, td.defaults, window)
+		if td.err {
+			if err == nil {
+				t.Errorf("%d: expected error", i)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%d: %s", i, err.Error())
+		}
+
+		if output.ContentType != td.expected.ContentType || output.StatusCode != td.expected.StatusCode || output.Body != td.expected.Body || len(output.Hosts) != len(td.expected.Hosts) {
+			t.Errorf("%d: expected %+v, output %+v", i, td.expected, output)
+		}
+	}
+}
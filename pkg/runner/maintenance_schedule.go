@@ -0,0 +1,310 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/color"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+// ScheduleMaintenance turns on maintenance at start time and turns off at end time in foreground
+// Schedule is saved in the state file, so it can be reconciled after act is restarted.
+func (r Runner) ScheduleMaintenance(ctx context.Context, out io.Writer, args []string) error {
+	if r.Config == nil {
+		return errors.New(constants.ConfigErrorMsg)
+	}
+
+	env := constants.EmptyString
+	if len(args) > 0 {
+		env = args[0]
+	}
+
+	state, err := r.getScheduleState(env)
+	if err != nil {
+		return err
+	}
+
+	window := schema.MaintenanceWindow{
+		Message:   r.Config.Maintenance.Message,
+		Start:     state.Start,
+		End:       state.End,
+		Timestamp: state.Start.UnixNano() / int64(time.Millisecond),
+	}
+
+	// responses are validated before the schedule starts
+	if _, err := r.renderMaintenanceResponses(window); err != nil {
+		return err
+	}
+
+	color.Yellow.Fprintf(out, "maintenance is scheduled from %s to %s\n", state.Start.Local().Format(time.RFC3339), state.End.Local().Format(time.RFC3339))
+	if err := r.confirm("Do you want to run the schedule? [y/n]"); err != nil {
+		return err
+	}
+
+	// MFA is asked now if needed, because nobody may answer the prompt at the end of schedule
+	if len(args) > 0 {
+		if err := r.ensureMFASessionUntil(state.End); err != nil {
+			return err
+		}
+	}
+
+	// changes are applied at the scheduled time without confirmation
+	flags := *r.Flag
+	flags.Yes = true
+	r.Flag = &flags
+
+	if err := saveMaintenanceState(constants.MaintenanceStatePath, state); err != nil {
+		return err
+	}
+
+	if err := r.runSchedule(ctx, out, args, &state, window); err != nil {
+		if ctx.Err() != nil {
+			return r.handleScheduleInterrupt(out, args, state)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// runSchedule waits for the window and applies maintenance
+func (r Runner) runSchedule(ctx context.Context, out io.Writer, args []string, state *schema.MaintenanceState, window schema.MaintenanceWindow) error {
+	if time.Now().Before(state.End) {
+		logrus.Infof("waiting for the start of maintenance: %s", state.Start.Local())
+		if err := sleepUntil(ctx, state.Start, constants.MaintenanceClockInterval); err != nil {
+			return err
+		}
+
+		// listeners which are already in maintenance are skipped, so restarted schedule is reconciled
+		if err := r.turnOnMaintenance(out, args, window); err != nil {
+			return err
+		}
+
+		state.Applied = true
+		if err := saveMaintenanceState(constants.MaintenanceStatePath, *state); err != nil {
+			return err
+		}
+
+		logrus.Infof("waiting for the end of maintenance: %s", state.End.Local())
+		if err := sleepUntil(ctx, state.End, constants.MaintenanceClockInterval); err != nil {
+			return err
+		}
+	}
+
+	err := retryWithBackoff(ctx, constants.MaxMaintenanceOffRetry, constants.MaintenanceOffRetryInterval, func() error {
+		return r.MaintenanceOff(out, args)
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		return fmt.Errorf("maintenance is still on, please run `act maintenance off` to turn it off: %w", err)
+	}
+
+	return removeMaintenanceState(constants.MaintenanceStatePath, state.Profile, state.Env)
+}
+
+// retryWithBackoff retries function with exponential backoff until it succeeds or context is cancelled
+func retryWithBackoff(ctx context.Context, retries int, interval time.Duration, fn func() error) error {
+	for retry := 0; ; retry++ {
+		err := fn()
+		if err == nil || retry >= retries {
+			return err
+		}
+
+		logrus.Warnf("%s, retrying after %s", err.Error(), interval)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+}
+
+// handleScheduleInterrupt asks whether to leave maintenance on when the schedule is cancelled
+func (r Runner) handleScheduleInterrupt(out io.Writer, args []string, state schema.MaintenanceState) error {
+	if !state.Applied {
+		color.Yellow.Fprintln(out, "schedule is cancelled before maintenance starts")
+		return removeMaintenanceState(constants.MaintenanceStatePath, state.Profile, state.Env)
+	}
+
+	// maintenance is left on if the prompt is interrupted again
+	leave := true
+	prompt := &survey.Confirm{
+		Message: "Schedule is cancelled. Do you want to leave maintenance on?",
+		Default: true,
+	}
+	if err := survey.AskOne(prompt, &leave, survey.WithStdio(os.Stdin, os.Stderr, os.Stderr)); err != nil {
+		logrus.Debugf("prompt is interrupted: %s", err.Error())
+		leave = true
+	}
+
+	if leave {
+		color.Yellow.Fprintf(out, "maintenance is left on. run `act maintenance schedule` again to resume, or `act maintenance off` to turn it off\n")
+		return nil
+	}
+
+	// error is printed here, because error of cancelled command is ignored
+	if err := r.MaintenanceOff(out, args); err != nil {
+		color.Red.Fprintln(out, err.Error())
+		return err
+	}
+
+	return removeMaintenanceState(constants.MaintenanceStatePath, state.Profile, state.Env)
+}
+
+// getScheduleState returns schedule from flags, or saved schedule if flags are empty
+func (r Runner) getScheduleState(env string) (schema.MaintenanceState, error) {
+	saved, err := loadMaintenanceStates(constants.MaintenanceStatePath)
+	if err != nil {
+		return schema.MaintenanceState{}, err
+	}
+
+	state, exists := saved[maintenanceStateKey(r.Config.Profile, env)]
+	if len(r.Flag.Start) == 0 && len(r.Flag.End) == 0 {
+		if !exists {
+			return state, errors.New("usage: act maintenance schedule [env] --start [time] --end [time]")
+		}
+		logrus.Infof("resume saved schedule of maintenance")
+		return state, nil
+	}
+
+	if exists {
+		logrus.Warnf("saved schedule from %s to %s is replaced", state.Start.Local(), state.End.Local())
+	}
+
+	start := time.Now()
+	if len(r.Flag.Start) > 0 {
+		if start, err = tools.ParseTime(r.Flag.Start); err != nil {
+			return state, err
+		}
+	}
+
+	if len(r.Flag.End) == 0 {
+		return state, errors.New("--end is required for the schedule")
+	}
+
+	end, err := tools.ParseTime(r.Flag.End)
+	if err != nil {
+		return state, err
+	}
+
+	if !end.After(start) {
+		return state, fmt.Errorf("end of maintenance should be after start: %s", end.Local())
+	}
+
+	if !end.After(time.Now()) {
+		return state, fmt.Errorf("end of maintenance is already passed: %s", end.Local())
+	}
+
+	return schema.MaintenanceState{
+		Profile: r.Config.Profile,
+		Env:     env,
+		Start:   start,
+		End:     end,
+		Applied: exists && state.Applied,
+	}, nil
+}
+
+// sleepUntil waits until the time or cancellation of context
+// Wall clock is polled, because monotonic clock stops while the machine sleeps.
+func sleepUntil(ctx context.Context, t time.Time, interval time.Duration) error {
+	// monotonic reading is stripped so that the time is compared with wall clock
+	t = t.Round(0)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !time.Now().Before(t) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// maintenanceStateKey returns key of schedule in the state file
+func maintenanceStateKey(profile, env string) string {
+	return fmt.Sprintf("%s/%s", profile, env)
+}
+
+// loadMaintenanceStates reads all schedules in the state file
+func loadMaintenanceStates(path string) (map[string]schema.MaintenanceState, error) {
+	states := map[string]schema.MaintenanceState{}
+	if !tools.FileExists(path) {
+		return states, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &states); err != nil {
+		return nil, fmt.Errorf("wrong maintenance state file %s: %s", path, err.Error())
+	}
+
+	return states, nil
+}
+
+// saveMaintenanceState writes schedule to the state file
+func saveMaintenanceState(path string, state schema.MaintenanceState) error {
+	states, err := loadMaintenanceStates(path)
+	if err != nil {
+		return err
+	}
+
+	state.UpdatedAt = time.Now()
+	states[maintenanceStateKey(state.Profile, state.Env)] = state
+
+	return writeMaintenanceStates(path, states)
+}
+
+// removeMaintenanceState removes finished schedule from the state file
+func removeMaintenanceState(path, profile, env string) error {
+	states, err := loadMaintenanceStates(path)
+	if err != nil {
+		return err
+	}
+
+	delete(states, maintenanceStateKey(profile, env))
+
+	return writeMaintenanceStates(path, states)
+}
+
+// writeMaintenanceStates writes all schedules to the state file
+func writeMaintenanceStates(path string, states map[string]schema.MaintenanceState) error {
+	b, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return tools.WriteFileAtomic(path, b, 0600)
+}
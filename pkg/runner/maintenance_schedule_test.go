@@ -0,0 +1,142 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestMaintenanceState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "act-maintenance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "state.json")
+	state := schema.MaintenanceState{
+		Profile: "default",
+		Env:     "prod",
+		Start:   time.Date(2020, 9, 1, 9, 0, 0, 0, time.UTC),
+		End:     time.Date(2020, 9, 1, 11, 0, 0, 0, time.UTC),
+		Applied: true,
+	}
+
+	if err := saveMaintenanceState(path, state); err != nil {
+		t.Fatal(err)
+	}
+
+	states, err := loadMaintenanceStates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved, ok := states["default/prod"]
+	if !ok || !saved.Applied || !saved.End.Equal(state.End) {
+		t.Errorf("expected %+v, output %+v", state, states)
+	}
+
+	if err := removeMaintenanceState(path, "default", "prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	if states, _ := loadMaintenanceStates(path); len(states) != 0 {
+		t.Errorf("state should be removed: %+v", states)
+	}
+}
+
+func TestSleepUntil(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testData := []struct {
+		name     string
+		ctx      context.Context
+		until    time.Time
+		expected error
+	}{
+		{name: "past", ctx: context.Background(), until: time.Now().Add(-time.Hour), expected: nil},
+		{name: "future", ctx: context.Background(), until: time.Now().Add(30 * time.Millisecond), expected: nil},
+		{name: "cancelled", ctx: cancelled, until: time.Now().Add(time.Hour), expected: context.Canceled},
+		{name: "cancelled after start", ctx: cancelled, until: time.Now().Add(-time.Hour), expected: context.Canceled},
+	}
+
+	for _, td := range testData {
+		start := time.Now()
+		err := sleepUntil(td.ctx, td.until, 10*time.Millisecond)
+		if err != td.expected {
+			t.Errorf("%s: expected %v, output %v", td.name, td.expected, err)
+		}
+
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%s: should return immediately, elapsed %s", td.name, elapsed)
+		}
+	}
+}
+
+func TestSleepUntilCancelWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(30*time.Millisecond, cancel)
+
+	if err := sleepUntil(ctx, time.Now().Add(time.Hour), 10*time.Millisecond); err != context.Canceled {
+		t.Errorf("expected %v, output %v", context.Canceled, err)
+	}
+}
+
+func TestWriteMaintenanceStatesAtomically(t *testing.T) {
+	dir, err := ioutil.TempDir("", "act-maintenance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "state.json")
+	if err := writeMaintenanceStates(path, map[string]schema.MaintenanceState{"default/prod": {Profile: "default", Env: "prod"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 || files[0].Name() != "state.json" {
+		t.Errorf("temporary file should not be left: %v", files)
+	}
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	calls := 0
+	err := retryWithBackoff(context.Background(), 3, 0, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("throttled")
+		}
+		return nil
+	})
+
+	if err != nil || calls != 3 {
+		t.Errorf("expected 3 calls without error, output %d calls, %v", calls, err)
+	}
+
+	calls = 0
+	if err := retryWithBackoff(context.Background(), 2, 0, func() error {
+		calls++
+		return errors.New("access denied")
+	}); err == nil || calls != 3 {
+		t.Errorf("error should be returned after 2 retries: %d calls, %v", calls, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls = 0
+	if err := retryWithBackoff(ctx, 2, time.Hour, func() error {
+		calls++
+		return errors.New("access denied")
+	}); err != context.Canceled || calls != 1 {
+		t.Errorf("retry should stop when context is cancelled: %d calls, %v", calls, err)
+	}
+}
@@ -0,0 +1,49 @@
+package runner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/DevopsArtFactory/act/pkg/aws"
+)
+
+func TestApplyRulePlans(t *testing.T) {
+	plans := []listenerPlan{
+		{listenerArn: "listener/app/first", plan: aws.RulePlan{}},
+		{listenerArn: "listener/app/broken", plan: aws.RulePlan{{Change: "unknown"}}},
+		{listenerArn: "listener/app/third", plan: aws.RulePlan{}},
+	}
+
+	var out bytes.Buffer
+	err := applyRulePlans(&out, plans, "run `act maintenance off prod`")
+	if err == nil {
+		t.Fatal("error should be returned if any listener fails")
+	}
+
+	if !strings.Contains(err.Error(), "1 of 3") || !strings.Contains(err.Error(), "act maintenance off prod") {
+		t.Errorf("wrong error: %s", err.Error())
+	}
+
+	// listeners after the failed one are also changed
+	changed, failed := out.String(), out.String()
+	changed = changed[:strings.Index(changed, "failed")]
+	failed = failed[strings.Index(failed, "failed"):]
+	if !strings.Contains(changed, "first") || !strings.Contains(changed, "third") || !strings.Contains(failed, "broken") {
+		t.Errorf("wrong output:\n%s", out.String())
+	}
+
+	if err := applyRulePlans(&out, plans[:1], "recovery"); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
+
+func TestMaintenanceOffCommand(t *testing.T) {
+	if output := maintenanceOffCommand(nil); output != "act maintenance off" {
+		t.Errorf("wrong command: %s", output)
+	}
+
+	if output := maintenanceOffCommand([]string{"prod"}); output != "act maintenance off prod" {
+		t.Errorf("wrong command: %s", output)
+	}
+}
@@ -0,0 +1,170 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/aws"
+	"github.com/DevopsArtFactory/act/pkg/color"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/vault"
+)
+
+// GetMFASerialNumber returns MFA serial number of user
+// If it is not required and not configured, empty string is returned when no MFA device is found.
+func (r Runner) GetMFASerialNumber(required bool) (string, error) {
+	mfaSerial, err := r.mfaClient().GetMFASerialNumber(r.Config)
+	if err != nil && !required && len(r.Config.MFASerial) == 0 && len(r.Config.AccountID) == 0 {
+		logrus.Debugf("assume role without MFA: %s", err.Error())
+		return constants.EmptyString, nil
+	}
+
+	return mfaSerial, err
+}
+
+// mfaClient returns client for MFA authentication in the partition of configuration
+func (r Runner) mfaClient() aws.Client {
+	return aws.NewMFAClient(aws.GetAwsSession(), r.Config)
+}
+
+// GetMFASession returns MFA session credentials in the vault, or creates new session with MFA
+// If it is not required, nil is returned for user who has no MFA device.
+func (r Runner) GetMFASession(store vault.Store, required bool) (*sts.Credentials, error) {
+	key := vault.MFASessionKey(r.Config.Profile)
+	item, err := store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if item != nil && !item.IsExpired() {
+		logrus.Debugf("MFA session is valid until %s", item.Expiration.Local())
+		return item.Credentials(), nil
+	}
+
+	duration := r.Config.MFASessionDuration
+	if duration > constants.MaxMFASessionDuration {
+		return nil, fmt.Errorf("mfa_session_duration cannot be more than %d seconds: %d", constants.MaxMFASessionDuration, duration)
+	}
+
+	mfaSerial, err := r.GetMFASerialNumber(required)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mfaSerial) == 0 {
+		return nil, nil
+	}
+
+	session, err := r.mfaClient().CheckMFAToken(mfaSerial, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Set(key, vault.NewItem(session)); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// ensureMFASessionUntil makes MFA session valid until the time, so that roles are assumed again without prompt
+// New session is created now if the cached session expires before the time.
+func (r Runner) ensureMFASessionUntil(until time.Time) error {
+	// session should outlive the expiration window and retries after the time
+	until = until.Add(2 * constants.DefaultExpirationWindow)
+
+	store, err := r.GetVault()
+	if err != nil {
+		return err
+	}
+
+	key := vault.MFASessionKey(r.Config.Profile)
+	item, err := store.Get(key)
+	if err != nil {
+		return err
+	}
+
+	if item != nil && item.Expiration.After(until) {
+		return nil
+	}
+
+	mfaSerial, err := r.GetMFASerialNumber(false)
+	if err != nil {
+		return err
+	}
+
+	// roles are assumed with access key which does not expire
+	if len(mfaSerial) == 0 {
+		return nil
+	}
+
+	if time.Now().Add(time.Duration(r.Config.MFASessionDuration) * time.Second).Before(until) {
+		return fmt.Errorf("MFA session of %d seconds expires before %s, please set longer mfa_session_duration", r.Config.MFASessionDuration, until.Local().Format(time.RFC3339))
+	}
+
+	if item != nil {
+		if err := store.Remove(key); err != nil {
+			return err
+		}
+	}
+
+	_, err = r.GetMFASession(store, false)
+	return err
+}
+
+// PrintMFAStatus prints remaining lifetime of MFA session
+func (r Runner) PrintMFAStatus(out io.Writer) error {
+	store, err := r.GetVault()
+	if err != nil {
+		return err
+	}
+
+	item, err := store.Get(vault.MFASessionKey(r.Config.Profile))
+	if err != nil {
+		return err
+	}
+
+	if item == nil || item.IsExpired() {
+		color.Yellow.Fprintf(out, "no active MFA session for profile: %s", r.Config.Profile)
+		return nil
+	}
+
+	color.Green.Fprintf(out, "MFA session of profile %s is active until %s (%s left)",
+		r.Config.Profile, item.Expiration.Local().Format(time.RFC3339), time.Until(item.Expiration).Round(time.Minute))
+
+	return nil
+}
+
+// MFALogout removes MFA session and role credentials assumed from it
+func (r Runner) MFALogout(out io.Writer) error {
+	store, err := r.GetVault()
+	if err != nil {
+		return err
+	}
+
+	keys, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	prefix := fmt.Sprintf("%s/", r.Config.Profile)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if err := store.Remove(key); err != nil {
+			return err
+		}
+		logrus.Debugf("%s is removed from the vault", key)
+	}
+
+	color.Green.Fprintf(out, "MFA session of profile %s is removed", r.Config.Profile)
+
+	return nil
+}
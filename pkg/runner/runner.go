@@ -1,20 +1,23 @@
 package runner
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"html/template"
 	"io"
+	"net"
 	"os"
-	"os/exec"
     "runtime"
 	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"text/template"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v2"
@@ -24,9 +27,11 @@ import (
 	"github.com/DevopsArtFactory/act/pkg/color"
 	"github.com/DevopsArtFactory/act/pkg/config"
 	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/printer"
 	"github.com/DevopsArtFactory/act/pkg/schema"
 	"github.com/DevopsArtFactory/act/pkg/templates"
 	"github.com/DevopsArtFactory/act/pkg/tools"
+	"github.com/DevopsArtFactory/act/pkg/vault"
 )
 
 type Runner struct {
@@ -54,16 +59,17 @@ func IsDarwin() bool {
 
 //CopyRDSToken copies RDS Token to clipboard
 func (r Runner) CopyRDSToken(env, region string) error {
-	config, err := config.GetConfig()
+	c, err := config.GetConfig()
 	if err != nil {
 		return err
 	}
 
-	if _, ok := config.AssumeRoles[env]; !ok {
+	chain, err := config.GetRoleChain(c, env)
+	if err != nil {
 		return errors.New("no assume role exists in config file")
 	}
 
-	targets := config.Databases[env]
+	targets := c.Databases[env]
 
 	if len(targets) == 0 {
 		return fmt.Errorf("no endpoints exist in configuration file for %s", env)
@@ -74,16 +80,33 @@ func (r Runner) CopyRDSToken(env, region string) error {
 		return err
 	}
 
-	creds := aws.GenerateCreds(config.AssumeRoles[env], config.Name)
+	store, err := r.GetVault()
+	if err != nil {
+		return err
+	}
+
+	creds, err := vault.GetOrCreate(store, vault.AssumeRoleKey(c.Profile, chain), func() (*sts.Credentials, error) {
+		session, err := r.GetMFASession(store, true)
+		if err != nil {
+			return nil, err
+		}
+
+		return config.GetChainedAssumeCreds(session, chain, c.Name, constants.MaxRoleChainingDuration)
+	})
+	if err != nil {
+		return err
+	}
 
-	authToken, err := aws.GetDBAuthToken(target, region, strings.Split(config.Name, "@")[0], creds)
+	authToken, err := aws.GetDBAuthToken(target, region, strings.Split(c.Name, "@")[0], aws.NewStaticCredentials(creds))
 	if err != nil {
 		return err
 	}
 
-	if err := tools.CopyToClipBoard(authToken); err != nil {
+	if err := r.CopyToClipboard(authToken); err != nil {
 		return err
 	}
+	logrus.Info("Token is copied to clipboard.")
+
 	return nil
 }
 
@@ -160,101 +183,131 @@ func (r Runner) InitConfiguration() error {
 
 // Setup gets assume role information and copy them to the clipboard
 func (r Runner) Setup(out io.Writer, args []string) error {
-	var target string
-	var err error
-
 	if r.Config == nil {
 		return errors.New(constants.ConfigErrorMsg)
 	}
 
-	if len(args) == 0 {
-		target, err = AskAssumeTarget(r.Config.AssumeRoles)
-		if err != nil {
-			return err
-		}
-	} else {
-		target = args[0]
+	_, chain, err := r.ResolveRoleChain(args)
+	if err != nil {
+		return err
 	}
 
-	var arn string
-	if len(r.Config.Alias) > 0 {
-		arn = r.Config.AssumeRoles[r.Config.Alias[target]]
+	assumeCreds, err := r.GetAssumeCreds(chain)
+	if err != nil {
+		return err
 	}
 
-	if len(arn) == 0 {
-		arn = r.Config.AssumeRoles[target]
+	format := r.Flag.Format
+	if len(format) == 0 {
+		format = printer.DetectFormat()
 	}
 
-	if err := CheckTarget(arn, target); err != nil {
+	var buf bytes.Buffer
+	if err := printer.Render(&buf, format, assumeCreds, r.GetRegion()); err != nil {
 		return err
 	}
 
-	duration := r.Config.Duration
-	if r.Flag.Duration > 0 {
-		duration = r.Flag.Duration
+	if viper.GetBool("raw-output") || format == constants.GithubActionsFormat {
+		fmt.Print(buf.String())
+		return nil
+	}
+
+	cb, err := r.GetClipboard()
+	if err != nil {
+		logrus.Debugf("show raw output: %s", err.Error())
+		fmt.Print(buf.String())
+		return nil
 	}
 
-	assumeCreds, err := config.GetAssumeCreds(arn, r.Config.Name, duration)
+	loc, err := time.LoadLocation("Asia/Seoul")
 	if err != nil {
 		return err
 	}
 
-	rawOutput := viper.GetBool("raw-output") || (IsDarwin() == false)
+	if err := r.copyWithClear(cb, buf.String()); err != nil {
+		color.Red.Fprintln(out, err.Error())
+		return err
+	}
+	color.Red.Fprintf(out, "Current token expired at: %s", assumeCreds.Expiration.In(loc))
+	color.Blue.Fprintln(out, "Assume Credentials copied to clipboard, please paste it.")
 
-	if rawOutput {
-		fmt.Printf("export AWS_ACCESS_KEY_ID=%s\n", *assumeCreds.AccessKeyId)
-		fmt.Printf("export AWS_SECRET_ACCESS_KEY=%s\n", *assumeCreds.SecretAccessKey)
-		fmt.Printf("export AWS_SESSION_TOKEN=%s\n", *assumeCreds.SessionToken)
-	} else {
-		loc, err := time.LoadLocation("Asia/Seoul")
+	return nil
+}
+
+// ResolveRoleChain returns the target environment and roles to assume, resolving alias if needed
+func (r Runner) ResolveRoleChain(args []string) (string, []schema.RoleHop, error) {
+	var target string
+	var err error
+
+	if len(args) == 0 {
+		target, err = AskAssumeTarget(config.GetEnvironments(r.Config))
 		if err != nil {
-			return err
+			return constants.EmptyString, nil, err
 		}
+	} else {
+		target = args[0]
+	}
 
-		pbcopy := exec.Command("pbcopy")
+	chain, err := config.GetRoleChain(r.Config, target)
+	if err != nil {
+		return constants.EmptyString, nil, err
+	}
 
-		in, _ := pbcopy.StdinPipe()
+	return target, chain, nil
+}
 
-		if err := pbcopy.Start(); err != nil {
-			return err
-		}
+// NewAssumeClient creates AWS client with the credentials of assume role for target environment
+func (r Runner) NewAssumeClient(args []string) (string, aws.Client, error) {
+	target, chain, err := r.ResolveRoleChain(args)
+	if err != nil {
+		return constants.EmptyString, aws.Client{}, err
+	}
 
-		if _, err := in.Write([]byte(fmt.Sprintf("export AWS_ACCESS_KEY_ID=%s\n", *assumeCreds.AccessKeyId))); err != nil {
-			return err
-		}
+	assumeCreds, err := r.GetAssumeCreds(chain)
+	if err != nil {
+		return constants.EmptyString, aws.Client{}, err
+	}
 
-		if _, err := in.Write([]byte(fmt.Sprintf("export AWS_SECRET_ACCESS_KEY=%s\n", *assumeCreds.SecretAccessKey))); err != nil {
-			return err
-		}
+	return target, aws.NewClient(aws.GetAwsSession(), r.GetRegion(), aws.NewStaticCredentials(assumeCreds)), nil
+}
 
-		if _, err := in.Write([]byte(fmt.Sprintf("export AWS_SESSION_TOKEN=%s\n", *assumeCreds.SessionToken))); err != nil {
-			return err
-		}
+// GetDuration returns duration of assume role session, flag value has priority
+// Chained roles default to the limit of role chaining if duration is not set.
+func (r Runner) GetDuration(chain []schema.RoleHop) int {
+	if r.Flag.Duration > 0 {
+		return r.Flag.Duration
+	}
 
-		if err := in.Close(); err != nil {
-			return err
-		}
+	if r.Config.Duration > 0 {
+		return r.Config.Duration
+	}
 
-		err = pbcopy.Wait()
-		if err != nil {
-			color.Red.Fprintln(out, err.Error())
-			return err
-		}
-		color.Red.Fprintf(out, "Current token expired at: %s", assumeCreds.Expiration.In(loc))
-		color.Blue.Fprintln(out, "Assume Credentials copied to clipboard, please paste it.")
+	// role chaining cannot have longer session than 1 hour
+	if len(chain) > 1 {
+		return constants.MaxRoleChainingDuration
 	}
-	return nil
+
+	return constants.DefaultAssumeDuration
+}
+
+// GetRegion returns region of flag or default region
+func (r Runner) GetRegion() string {
+	if len(r.Flag.Region) > 0 {
+		return r.Flag.Region
+	}
+
+	return constants.DefaultRegion
 }
 
 // PrintAssumeList prints all accounts registered for assuming
 func (r Runner) PrintAssumeList(out io.Writer) error {
-	config, err := config.GetConfig()
+	c, err := config.GetConfig()
 	if err != nil {
 		return err
 	}
 
-	color.Blue.Fprintf(out, "[ name: %s, profile: %s ] Account List", config.Name, config.Profile)
-	for key := range config.AssumeRoles {
+	color.Blue.Fprintf(out, "[ name: %s, profile: %s ] Account List", c.Name, c.Profile)
+	for _, key := range config.GetEnvironments(c) {
 		fmt.Println(key)
 	}
 
@@ -270,17 +323,36 @@ func (r Runner) Who(out io.Writer) error {
 	return nil
 }
 
-func (r Runner) HasIP(out io.Writer, args []string) error {
-	if len(args) == 0 {
+func (r Runner) HasIP(ctx context.Context, out io.Writer, args []string) error {
+	// stdin cannot be used for both IP addresses and selection of web ACL
+	if r.Flag.File == "-" && !r.Flag.All && len(r.Flag.WebACL) == 0 {
+		return errors.New("--all or --web-acl should be specified when IP addresses are read from stdin")
+	}
+
+	targets, err := r.getHasIPTargets(args)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
 		return errors.New("you have to specify at least 1 ip address")
 	}
 
-	targetList, err := ParseTargetList(args)
+	targetList, err := ParseTargetList(targets)
 	if err != nil {
 		return err
 	}
 
-	acl, err := r.SelectTargetACL([]string{})
+	if r.Flag.All {
+		return r.SearchIPInAllACLs(ctx, out, targetList)
+	}
+
+	var aclArgs []string
+	if len(r.Flag.WebACL) > 0 {
+		aclArgs = append(aclArgs, r.Flag.WebACL)
+	}
+
+	acl, err := r.SelectTargetACL(aclArgs)
 	if err != nil {
 		return err
 	}
@@ -304,7 +376,7 @@ func (r Runner) DescribeWebACL(out io.Writer, args []string) error {
 		return err
 	}
 
-	info, err := r.AWSClient.DescribeWebACL(target)
+	info, err := r.AWSClient.DescribeWebACL(target, aws.NewWafLimiter(r.Flag.Concurrency))
 	if err != nil {
 		return err
 	}
@@ -328,7 +400,12 @@ func (r Runner) RenewCredentials(out io.Writer) error {
 		return errors.New(constants.ConfigErrorMsg)
 	}
 
-	if err := r.AWSClient.CheckMFAToken(c.Name); err != nil {
+	store, err := r.GetVault()
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.GetMFASession(store, true); err != nil {
 		return err
 	}
 
@@ -399,8 +476,21 @@ func (r Runner) RenewCredentials(out io.Writer) error {
 }
 
 // EcrLogin returns authorization data for ecr-login
-func (r Runner) EcrLogin(out io.Writer) error {
-	data, err := r.AWSClient.GetAuthorizeToken()
+func (r Runner) EcrLogin(out io.Writer, args []string) error {
+	client := r.AWSClient
+	if len(args) > 0 {
+		if r.Config == nil {
+			return errors.New(constants.ConfigErrorMsg)
+		}
+
+		var err error
+		_, client, err = r.NewAssumeClient(args)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := client.GetAuthorizeToken()
 	if err != nil {
 		return err
 	}
@@ -410,7 +500,7 @@ func (r Runner) EcrLogin(out io.Writer) error {
 		return err
 	}
 
-	if err := tools.CopyToClipBoard(cmd); err != nil {
+	if err := r.CopyToClipboard(cmd); err != nil {
 		return err
 	}
 
@@ -435,11 +525,9 @@ func AskBaseAccountName() (string, error) {
 }
 
 // AskAssumeTarget asks assume target
-func AskAssumeTarget(assumeList map[string]string) (string, error) {
+func AskAssumeTarget(keys []string) (string, error) {
 	var target string
 
-	keys := tools.GetKeys(assumeList)
-
 	sort.Strings(keys)
 
 	prompt := &survey.Select{
@@ -461,15 +549,6 @@ func printInitFile(b []byte) error {
 	return err
 }
 
-// checkTarget checks if target is in the list
-func CheckTarget(arn, target string) error {
-	if len(arn) > 0 {
-		return nil
-	}
-
-	return fmt.Errorf("%s is not registered in the assume list", target)
-}
-
 // PrintWebACL prints information
 func PrintWebACL(out io.Writer, info *schema.WebACL) error {
 	var data = struct {
@@ -501,7 +580,11 @@ func ParseTargetList(args []string) ([]string, error) {
 		}
 
 		if len(strings.Split(ip, "/")) == 1 {
-			ip += "/32"
+			if strings.Contains(ip, ":") {
+				ip += "/128"
+			} else {
+				ip += "/32"
+			}
 		}
 
 		ret = append(ret, ip)
@@ -512,6 +595,10 @@ func ParseTargetList(args []string) ([]string, error) {
 
 // IsValidAddress checks if address is valid or not
 func IsValidAddress(ip string) error {
+	if strings.Contains(ip, ":") {
+		return isValidIPv6Address(ip)
+	}
+
 	if strings.Count(ip, ".") != 3 {
 		return fmt.Errorf("wrong IP address: %s", ip)
 	}
@@ -547,59 +634,66 @@ func IsValidAddress(ip string) error {
 	return nil
 }
 
+// isValidIPv6Address checks if IPv6 address is valid or not
+func isValidIPv6Address(ip string) error {
+	cidr := ip
+	if !strings.Contains(cidr, "/") {
+		cidr += "/128"
+	}
+
+	if _, _, err := net.ParseCIDR(cidr); err != nil {
+		return fmt.Errorf("wrong IPv6 address: %s", ip)
+	}
+
+	return nil
+}
+
 // SelectTargetACL makes a user choose ACL from the list
-func (r Runner) SelectTargetACL(args []string) (string, error) {
-	var target string
-	var err error
+func (r Runner) SelectTargetACL(args []string) (schema.WebACLSummary, error) {
+	version, scope, err := r.getWafOptions()
+	if err != nil {
+		return schema.WebACLSummary{}, err
+	}
 
 	if len(args) == 0 {
-		target, err = r.AWSClient.SelectACL()
-		if err != nil {
-			return constants.EmptyString, err
-		}
-	} else {
-		target = args[0]
+		return r.AWSClient.SelectACL(version, scope)
 	}
 
-	return target, err
+	return r.AWSClient.FindWebACL(args[0], version, scope)
+}
+
+// getWafOptions returns version and scope of WAF from flags
+func (r Runner) getWafOptions() (string, string, error) {
+	version := strings.ToLower(r.Flag.WafVersion)
+	if len(version) == 0 {
+		version = constants.AutoWafVersion
+	}
+
+	if !tools.IsStringInArray(version, constants.WafVersions) {
+		return constants.EmptyString, constants.EmptyString, fmt.Errorf("waf version should be one of %s: %s", strings.Join(constants.WafVersions, ", "), r.Flag.WafVersion)
+	}
+
+	scope := strings.ToUpper(r.Flag.Scope)
+	if len(scope) > 0 && !tools.IsStringInArray(scope, constants.WafScopes) {
+		return constants.EmptyString, constants.EmptyString, fmt.Errorf("scope should be one of %s: %s", strings.Join(constants.WafScopes, ", "), r.Flag.Scope)
+	}
+
+	return version, scope, nil
 }
 
 // CheckIfTargetExistsInACL checks if target exists in ACL
-func (r Runner) CheckIfTargetExistsInACL(targetList []string, acl string) ([]schema.IPCheckResult, error) {
-	info, err := r.AWSClient.DescribeWebACL(acl)
+func (r Runner) CheckIfTargetExistsInACL(targetList []string, acl schema.WebACLSummary) ([]schema.IPCheckResult, error) {
+	info, err := r.AWSClient.DescribeWebACL(acl, aws.NewWafLimiter(r.Flag.Concurrency))
 	if err != nil {
 		return nil, err
 	}
 
 	ret := []schema.IPCheckResult{}
 	for _, t := range targetList {
-		tmp := schema.IPCheckResult{
-			IP:     t,
-			Result: false,
-		}
-		if info.Rules != nil && len(info.Rules) > 0 {
-			for _, rule := range info.Rules {
-				isFinished := false
-				for _, ds := range rule.IPDataSet {
-					if tools.IsStringInArray(t, ds.IPList) {
-						tmp.IPSetID = ds.ID
-						tmp.Result = true
-					}
-
-					if tmp.Result {
-						isFinished = true
-						break
-					}
-				}
-				if isFinished {
-					break
-				}
-			}
-		}
-		ret = append(ret, tmp)
+		ret = append(ret, MatchTargetInACL(t, info))
 	}
 
-	return ret, err
+	return ret, nil
 }
 
 // PrintHasIPResult prints search result
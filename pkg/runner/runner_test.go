@@ -3,6 +3,9 @@ package runner
 import (
 	"fmt"
 	"testing"
+
+	"github.com/DevopsArtFactory/act/pkg/builder"
+	"github.com/DevopsArtFactory/act/pkg/schema"
 )
 
 func TestIsValidAddress(t *testing.T) {
@@ -26,6 +29,14 @@ func TestIsValidAddress(t *testing.T) {
 			input:    "123.421.0.0/32",
 			expected: fmt.Errorf("each class number should be between 0 and 255: %s", "123.421.0.0/32"),
 		},
+		{
+			input:    "2001:db8::/32",
+			expected: nil,
+		},
+		{
+			input:    "2001:db8::/129",
+			expected: fmt.Errorf("wrong IPv6 address: %s", "2001:db8::/129"),
+		},
 	}
 
 	for _, td := range testData {
@@ -35,3 +46,27 @@ func TestIsValidAddress(t *testing.T) {
 		}
 	}
 }
+
+func TestGetDuration(t *testing.T) {
+	single := []schema.RoleHop{{RoleArn: "arn:aws:iam::111111111111:role/admin"}}
+	chain := append(single, schema.RoleHop{RoleArn: "arn:aws:iam::222222222222:role/admin"})
+
+	testData := []struct {
+		flag     int
+		config   int
+		chain    []schema.RoleHop
+		expected int
+	}{
+		{chain: single, expected: 7200},
+		{chain: chain, expected: 3600},
+		{config: 1800, chain: chain, expected: 1800},
+		{flag: 900, config: 1800, chain: chain, expected: 900},
+	}
+
+	for _, td := range testData {
+		r := Runner{Flag: &builder.Flags{Duration: td.flag}, Config: &schema.Config{Duration: td.config}}
+		if output := r.GetDuration(td.chain); output != td.expected {
+			t.Errorf("expected: %d, output: %d", td.expected, output)
+		}
+	}
+}
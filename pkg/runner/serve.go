@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/DevopsArtFactory/act/pkg/color"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/server"
+)
+
+// Serve runs local credential server with assume role credentials of target environment
+func (r Runner) Serve(ctx context.Context, out io.Writer, args []string) error {
+	if r.Config == nil {
+		return errors.New(constants.ConfigErrorMsg)
+	}
+
+	target, chain, err := r.ResolveRoleChain(args)
+	if err != nil {
+		return err
+	}
+
+	arn := chain[len(chain)-1].RoleArn
+	provider := func() (*sts.Credentials, error) {
+		return r.GetAssumeCreds(chain)
+	}
+
+	// assume role before serving in order to fail fast
+	if _, err := provider(); err != nil {
+		return err
+	}
+
+	token := r.Flag.Token
+	if len(token) == 0 {
+		token, err = server.GenerateToken()
+		if err != nil {
+			return err
+		}
+	}
+
+	address := r.Flag.Address
+	if len(address) == 0 {
+		address = constants.DefaultServeAddress
+	}
+
+	port := r.Flag.Port
+	if port == 0 {
+		port = constants.DefaultServePort
+	}
+
+	addr := net.JoinHostPort(address, strconv.Itoa(port))
+	srv, err := server.New(addr, token, arn, provider)
+	if err != nil {
+		return err
+	}
+
+	color.Blue.Fprintf(out, "Serving credentials of %s on %s, press Ctrl+C to stop", target, addr)
+	fmt.Fprintf(out, "export AWS_CONTAINER_CREDENTIALS_FULL_URI=http://%s%s\n", addr, server.ECSCredentialsPath)
+	fmt.Fprintf(out, "export AWS_CONTAINER_AUTHORIZATION_TOKEN='Bearer %s'\n", token)
+
+	return srv.Run(ctx)
+}
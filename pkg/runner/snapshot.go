@@ -0,0 +1,181 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+
+	"github.com/DevopsArtFactory/act/pkg/aws"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+// capacityStore stores capacity snapshot of autoscaling groups
+type capacityStore interface {
+	// Get returns snapshot of autoscaling group, or nil if it does not exist
+	Get(group *autoscaling.Group) (*schema.ASGCapacity, error)
+	Set(group *autoscaling.Group, capacity schema.ASGCapacity) error
+}
+
+// tagCapacityStore stores snapshot in the tag of autoscaling group
+type tagCapacityStore struct {
+	client aws.Client
+}
+
+// fileCapacityStore stores snapshots of all autoscaling groups in a JSON file
+type fileCapacityStore struct {
+	path string
+}
+
+// newCapacityStore creates store of capacity snapshot in the configuration
+func newCapacityStore(client aws.Client, snapshot, path string) (capacityStore, error) {
+	switch snapshot {
+	case constants.EmptyString, constants.TagSnapshot:
+		return tagCapacityStore{client: client}, nil
+	case constants.FileSnapshot:
+		if len(path) == 0 {
+			path = constants.DefaultLoadtestState
+		}
+		return fileCapacityStore{path: path}, nil
+	}
+
+	return nil, fmt.Errorf("snapshot type is not supported: %s", snapshot)
+}
+
+// Get reads snapshot from the tag
+func (s tagCapacityStore) Get(group *autoscaling.Group) (*schema.ASGCapacity, error) {
+	value, ok := aws.GetASGTag(group, constants.CapacitySnapshotTag)
+	if !ok {
+		return nil, nil
+	}
+
+	var capacity schema.ASGCapacity
+	if err := json.Unmarshal([]byte(value), &capacity); err != nil {
+		return nil, fmt.Errorf("wrong capacity snapshot in tag of %s: %s", *group.AutoScalingGroupName, err.Error())
+	}
+
+	return &capacity, nil
+}
+
+// Set writes snapshot to the tag
+func (s tagCapacityStore) Set(group *autoscaling.Group, capacity schema.ASGCapacity) error {
+	b, err := json.Marshal(capacity)
+	if err != nil {
+		return err
+	}
+
+	return s.client.SetASGTag(group.AutoScalingGroupName, constants.CapacitySnapshotTag, string(b))
+}
+
+// Get reads snapshot from the state file
+func (s fileCapacityStore) Get(group *autoscaling.Group) (*schema.ASGCapacity, error) {
+	snapshots, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := capacitySnapshotKey(group)
+	if err != nil {
+		return nil, err
+	}
+
+	capacity, ok := snapshots[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &capacity, nil
+}
+
+// Set writes snapshot to the state file
+func (s fileCapacityStore) Set(group *autoscaling.Group, capacity schema.ASGCapacity) error {
+	key, err := capacitySnapshotKey(group)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := s.read()
+	if err != nil {
+		return err
+	}
+	snapshots[key] = capacity
+
+	b, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+
+	return tools.WriteFileAtomic(s.path, b, 0600)
+}
+
+// capacitySnapshotKey returns key of snapshot in the state file
+// Account and region are included, because groups of the same name can exist in other accounts or regions.
+func capacitySnapshotKey(group *autoscaling.Group) (string, error) {
+	if group.AutoScalingGroupARN == nil {
+		return constants.EmptyString, fmt.Errorf("arn of autoscaling group %s is unknown", *group.AutoScalingGroupName)
+	}
+
+	parsed, err := arn.Parse(*group.AutoScalingGroupARN)
+	if err != nil {
+		return constants.EmptyString, fmt.Errorf("wrong arn of autoscaling group %s: %s", *group.AutoScalingGroupName, err.Error())
+	}
+
+	return fmt.Sprintf("%s/%s/%s", parsed.AccountID, parsed.Region, *group.AutoScalingGroupName), nil
+}
+
+// read returns all snapshots in the state file
+func (s fileCapacityStore) read() (map[string]schema.ASGCapacity, error) {
+	snapshots := map[string]schema.ASGCapacity{}
+	if !tools.FileExists(s.path) {
+		return snapshots, nil
+	}
+
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &snapshots); err != nil {
+		return nil, fmt.Errorf("wrong loadtest state file %s: %s", s.path, err.Error())
+	}
+
+	return snapshots, nil
+}
+
+// getCapacityOverride returns capacity override of autoscaling group
+// Exact name has priority, and the longest pattern which is contained in the name is used otherwise.
+func getCapacityOverride(overrides map[string]schema.ASGCapacity, name string) (*schema.ASGCapacity, bool) {
+	if capacity, ok := overrides[name]; ok {
+		return &capacity, true
+	}
+
+	var matched string
+	for pattern := range overrides {
+		if strings.Contains(name, pattern) && len(pattern) > len(matched) {
+			matched = pattern
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil, false
+	}
+
+	capacity := overrides[matched]
+	return &capacity, true
+}
+
+// isZeroCapacity checks if autoscaling group is already scaled to zero
+func isZeroCapacity(capacity schema.ASGCapacity) bool {
+	return capacity.MinSize == 0 && capacity.DesiredCapacity == 0 && capacity.MaxSize == 0
+}
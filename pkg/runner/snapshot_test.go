@@ -0,0 +1,136 @@
+package runner
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestFileCapacityStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "act-loadtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := fileCapacityStore{path: filepath.Join(dir, "state.json")}
+	group := newTestASG("123456789012", "ap-northeast-2", "loadtest-api-v001")
+
+	capacity, err := store.Get(group)
+	if err != nil || capacity != nil {
+		t.Fatalf("snapshot should not exist: %v, %v", capacity, err)
+	}
+
+	expected := schema.ASGCapacity{MinSize: 2, DesiredCapacity: 3, MaxSize: 6, SuspendedProcesses: []string{"AZRebalance"}}
+	if err := store.Set(group, expected); err != nil {
+		t.Fatal(err)
+	}
+
+	capacity, err = store.Get(group)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(*capacity, expected) {
+		t.Errorf("expected %+v, output %+v", expected, *capacity)
+	}
+
+	// groups of the same name in other accounts or regions have their own snapshots
+	for _, other := range []*autoscaling.Group{
+		newTestASG("210987654321", "ap-northeast-2", "loadtest-api-v001"),
+		newTestASG("123456789012", "us-east-1", "loadtest-api-v001"),
+	} {
+		capacity, err := store.Get(other)
+		if err != nil || capacity != nil {
+			t.Errorf("snapshot of %s should not exist: %v, %v", *other.AutoScalingGroupARN, capacity, err)
+		}
+	}
+}
+
+// newTestASG returns autoscaling group with arn of the account and region
+func newTestASG(account, region, name string) *autoscaling.Group {
+	return &autoscaling.Group{
+		AutoScalingGroupName: aws.String(name),
+		AutoScalingGroupARN:  aws.String(fmt.Sprintf("arn:aws:autoscaling:%s:%s:autoScalingGroup:1234:autoScalingGroupName/%s", region, account, name)),
+	}
+}
+
+func TestGetCapacityOverride(t *testing.T) {
+	overrides := map[string]schema.ASGCapacity{
+		"loadtest":            {MinSize: 1, DesiredCapacity: 1, MaxSize: 1},
+		"loadtest-api":        {MinSize: 2, DesiredCapacity: 2, MaxSize: 4},
+		"loadtest-api-worker": {MinSize: 3, DesiredCapacity: 3, MaxSize: 3},
+	}
+
+	testData := []struct {
+		name     string
+		expected int64
+	}{
+		{name: "loadtest-api-worker", expected: 3},
+		{name: "loadtest-api-v001", expected: 2},
+		{name: "loadtest-batch-v001", expected: 1},
+		{name: "prod-api-v001", expected: 0},
+	}
+
+	for _, td := range testData {
+		capacity, ok := getCapacityOverride(overrides, td.name)
+		if td.expected == 0 {
+			if ok {
+				t.Errorf("%s should not have override", td.name)
+			}
+			continue
+		}
+
+		if !ok || capacity.MinSize != td.expected {
+			t.Errorf("%s: expected min %d, output %v", td.name, td.expected, capacity)
+		}
+	}
+}
+
+func TestSuspendedProcessesInSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "act-loadtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := fileCapacityStore{path: filepath.Join(dir, "state.json")}
+	testData := []struct {
+		group    *autoscaling.Group
+		capacity schema.ASGCapacity
+	}{
+		{
+			// snapshot without suspended processes resumes all processes
+			group:    newTestASG("123456789012", "ap-northeast-2", "loadtest-api-v001"),
+			capacity: schema.ASGCapacity{MinSize: 1, SuspendedProcesses: []string{}},
+		},
+		{
+			// capacity without suspended processes leaves processes as they are
+			group:    newTestASG("123456789012", "ap-northeast-2", "loadtest-api-v002"),
+			capacity: schema.ASGCapacity{MinSize: 1},
+		},
+	}
+
+	for _, td := range testData {
+		if err := store.Set(td.group, td.capacity); err != nil {
+			t.Fatal(err)
+		}
+
+		capacity, err := store.Get(td.group)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if (capacity.SuspendedProcesses == nil) != (td.capacity.SuspendedProcesses == nil) {
+			t.Errorf("%s: expected %#v, output %#v", *td.group.AutoScalingGroupName, td.capacity.SuspendedProcesses, capacity.SuspendedProcesses)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/DevopsArtFactory/act/pkg/color"
+	"github.com/DevopsArtFactory/act/pkg/config"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+	"github.com/DevopsArtFactory/act/pkg/vault"
+)
+
+// GetVault creates credential store of current configuration
+func (r Runner) GetVault() (vault.Store, error) {
+	return vault.New(r.Config.Vault.Type, r.Config.Vault.Path)
+}
+
+// GetAssumeCreds returns cached credentials of roles in the vault, or assumes roles if there is no valid one
+func (r Runner) GetAssumeCreds(chain []schema.RoleHop) (*sts.Credentials, error) {
+	store, err := r.GetVault()
+	if err != nil {
+		return nil, err
+	}
+
+	return vault.GetOrCreate(store, vault.AssumeRoleKey(r.Config.Profile, chain), func() (*sts.Credentials, error) {
+		// roles are assumed from MFA session, so MFA is not asked until the session is expired
+		session, err := r.GetMFASession(store, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return config.GetChainedAssumeCreds(session, chain, r.Config.Name, r.GetDuration(chain))
+	})
+}
+
+// ListVault prints all credentials cached in the vault
+func (r Runner) ListVault(out io.Writer) error {
+	store, err := r.GetVault()
+	if err != nil {
+		return err
+	}
+
+	keys, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		color.Blue.Fprintln(out, "No credential is cached in the vault")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "KEY\tEXPIRATION\tSTATUS")
+	for _, key := range keys {
+		item, err := store.Get(key)
+		if err != nil {
+			return err
+		}
+
+		if item == nil {
+			continue
+		}
+
+		status := "valid"
+		if item.IsExpired() {
+			status = "expired"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", key, item.Expiration.Local().Format(time.RFC3339), status)
+	}
+
+	return w.Flush()
+}
+
+// RemoveVaultItem removes cached credentials from the vault
+func (r Runner) RemoveVaultItem(out io.Writer, args []string) error {
+	store, err := r.GetVault()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range args {
+		if err := store.Remove(key); err != nil {
+			return fmt.Errorf("%s: %s", key, err.Error())
+		}
+		color.Green.Fprintf(out, "%s is removed from the vault", key)
+	}
+
+	return nil
+}
+
+// ClearVault removes all credentials in the vault
+func (r Runner) ClearVault(out io.Writer) error {
+	store, err := r.GetVault()
+	if err != nil {
+		return err
+	}
+
+	if err := tools.AskContinue("Are you sure to remove all credentials in the vault? "); err != nil {
+		return errors.New("clearing vault has been canceled")
+	}
+
+	if err := store.Clear(); err != nil {
+		return err
+	}
+	color.Green.Fprintln(out, "vault is successfully cleared")
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/DevopsArtFactory/act/pkg/aws"
+	"github.com/DevopsArtFactory/act/pkg/color"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+// AddIPToIPSet adds addresses to IP set
+func (r Runner) AddIPToIPSet(out io.Writer, args []string) error {
+	return r.changeIPSet(out, args, true)
+}
+
+// RemoveIPFromIPSet removes addresses from IP set
+func (r Runner) RemoveIPFromIPSet(out io.Writer, args []string) error {
+	return r.changeIPSet(out, args, false)
+}
+
+// ipSetChange is the change of addresses in an IP set
+type ipSetChange struct {
+	ipSet    schema.IPSetSummary
+	current  []string
+	toAdd    []string
+	toRemove []string
+}
+
+// changeIPSet adds or removes addresses in IP set
+func (r Runner) changeIPSet(out io.Writer, args []string, add bool) error {
+	parsed, err := ParseTargetList(args)
+	if err != nil {
+		return err
+	}
+	targets := normalizeTargetList(parsed)
+
+	ipSet, err := r.SelectTargetIPSet()
+	if err != nil {
+		return err
+	}
+
+	current, err := r.AWSClient.GetIPSetAddresses(ipSet)
+	if err != nil {
+		return err
+	}
+
+	change := ipSetChange{ipSet: ipSet, current: current}
+	if add {
+		change.toAdd = diffAddresses(targets, current)
+	} else {
+		change.toRemove = intersectAddresses(current, targets)
+	}
+
+	return r.applyIPSetChanges(out, []ipSetChange{change})
+}
+
+// applyIPSetChanges shows diff of IP sets and applies it after confirmation
+func (r Runner) applyIPSetChanges(out io.Writer, changes []ipSetChange) error {
+	var targets []ipSetChange
+	for _, change := range changes {
+		if len(change.toAdd) > 0 || len(change.toRemove) > 0 {
+			targets = append(targets, change)
+		}
+	}
+
+	if len(targets) == 0 {
+		color.Green.Fprintln(out, "IP set is already up to date")
+		return nil
+	}
+
+	// caller is checked before the change so that every change can be recorded
+	identity, err := r.AWSClient.GetCallerIdentity()
+	if err != nil {
+		return err
+	}
+
+	// audit log is checked before the change so that no change is left without record
+	if err := checkWafAudit(constants.WafAuditLogPath); err != nil {
+		return fmt.Errorf("cannot write audit log %s: %w", constants.WafAuditLogPath, err)
+	}
+
+	for _, change := range targets {
+		printIPSetDiff(out, change)
+	}
+
+	if err := r.confirm("Do you want to update IP set? [y/n]"); err != nil {
+		return err
+	}
+
+	for _, change := range targets {
+		if err := r.AWSClient.UpdateIPSetAddresses(change.ipSet, change.toAdd, change.toRemove); err != nil {
+			return fmt.Errorf("failed to update IP set %s: %w", change.ipSet.Name, err)
+		}
+
+		record := schema.WafAuditRecord{
+			Time:      time.Now(),
+			Caller:    *identity.Arn,
+			Account:   *identity.Account,
+			IPSetID:   change.ipSet.ID,
+			IPSetName: change.ipSet.Name,
+			Version:   change.ipSet.Version,
+			Scope:     change.ipSet.Scope,
+			Added:     change.toAdd,
+			Removed:   change.toRemove,
+		}
+		if err := writeWafAudit(constants.WafAuditLogPath, record); err != nil {
+			return fmt.Errorf("IP set %s is updated, but failed to write audit log: %w", change.ipSet.Name, err)
+		}
+
+		color.Green.Fprintf(out, "IP set %s is updated\n", change.ipSet.Name)
+	}
+
+	return nil
+}
+
+// SelectTargetIPSet returns IP set of --ip-set flag, or makes a user choose IP set from the list
+func (r Runner) SelectTargetIPSet() (schema.IPSetSummary, error) {
+	version, scope, err := r.getWafOptions()
+	if err != nil {
+		return schema.IPSetSummary{}, err
+	}
+
+	if len(r.Flag.IPSet) == 0 {
+		return r.AWSClient.SelectIPSet(version, scope)
+	}
+
+	return r.AWSClient.FindIPSet(r.Flag.IPSet, version, scope)
+}
+
+// printIPSetDiff prints addresses which will be added or removed
+func printIPSetDiff(out io.Writer, change ipSetChange) {
+	color.Yellow.Fprintf(out, "IP set %s / %s [%s] will be changed\n", change.ipSet.Name, change.ipSet.ID, aws.GetIPSetLabel(change.ipSet))
+	for _, address := range change.toRemove {
+		color.Red.Fprintf(out, "  - %s\n", address)
+	}
+	for _, address := range change.toAdd {
+		color.Green.Fprintf(out, "  + %s\n", address)
+	}
+
+	after := aws.ApplyAddressChanges(change.current, change.toAdd, change.toRemove)
+	fmt.Fprintf(out, "Plan: %d to add, %d to remove. Addresses: %d -> %d\n", len(change.toAdd), len(change.toRemove), len(change.current), len(after))
+}
+
+// diffAddresses returns addresses which are not in others
+func diffAddresses(addresses, others []string) []string {
+	exists := map[string]bool{}
+	for _, address := range others {
+		exists[normalizeCIDR(address)] = true
+	}
+
+	var ret []string
+	for _, address := range addresses {
+		if !exists[normalizeCIDR(address)] {
+			exists[normalizeCIDR(address)] = true
+			ret = append(ret, address)
+		}
+	}
+
+	return ret
+}
+
+// intersectAddresses returns addresses which are also in others, with the original format of addresses
+func intersectAddresses(addresses, others []string) []string {
+	exists := map[string]bool{}
+	for _, address := range others {
+		exists[normalizeCIDR(address)] = true
+	}
+
+	var ret []string
+	for _, address := range addresses {
+		if exists[normalizeCIDR(address)] {
+			ret = append(ret, address)
+		}
+	}
+
+	return ret
+}
+
+// normalizeCIDR returns canonical form of CIDR, so that addresses with different notations can be compared
+func normalizeCIDR(address string) string {
+	ipNet, err := parseCIDR(address)
+	if err != nil {
+		return address
+	}
+
+	return ipNet.String()
+}
+
+// normalizeTargetList returns canonical form of addresses, which are accepted by WAF
+func normalizeTargetList(targets []string) []string {
+	var ret []string
+	for _, target := range targets {
+		ret = append(ret, normalizeCIDR(target))
+	}
+
+	return ret
+}
+
+// checkWafAudit checks if audit log can be written
+func checkWafAudit(path string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeWafAudit appends record of WAF change to audit log
+func writeWafAudit(path string, record schema.WafAuditRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
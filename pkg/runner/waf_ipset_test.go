@@ -0,0 +1,85 @@
+package runner
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestDiffAddresses(t *testing.T) {
+	current := []string{"1.1.1.1/32", "2001:0db8::/32"}
+
+	toAdd := diffAddresses([]string{"1.1.1.1/32", "2001:db8::/32", "3.3.3.3/32", "3.3.3.3/32"}, current)
+	if !reflect.DeepEqual(toAdd, []string{"3.3.3.3/32"}) {
+		t.Errorf("wrong addresses to add: %v", toAdd)
+	}
+
+	// addresses are removed with the original format in IP set
+	toRemove := intersectAddresses(current, []string{"2001:db8::/32", "4.4.4.4/32"})
+	if !reflect.DeepEqual(toRemove, []string{"2001:0db8::/32"}) {
+		t.Errorf("wrong addresses to remove: %v", toRemove)
+	}
+}
+
+func TestNormalizeTargetList(t *testing.T) {
+	output := normalizeTargetList([]string{"1.2.3.4/24", "2001:0DB8::1/128"})
+	if !reflect.DeepEqual(output, []string{"1.2.3.0/24", "2001:db8::1/128"}) {
+		t.Errorf("wrong normalized addresses: %v", output)
+	}
+}
+
+func TestWriteWafAudit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "act-waf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	for _, id := range []string{"first", "second"} {
+		if err := writeWafAudit(path, schema.WafAuditRecord{IPSetID: id, Added: []string{"1.1.1.1/32"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records, output %d", len(lines))
+	}
+
+	var record schema.WafAuditRecord
+	if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+		t.Fatal(err)
+	}
+
+	if record.IPSetID != "second" {
+		t.Errorf("expected second, output %s", record.IPSetID)
+	}
+}
+
+func TestCheckWafAudit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "act-waf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := checkWafAudit(filepath.Join(dir, "audit.log")); err != nil {
+		t.Errorf("audit log should be writable: %s", err.Error())
+	}
+
+	if err := checkWafAudit(filepath.Join(dir, "missing", "audit.log")); err == nil {
+		t.Errorf("error should be returned if audit log cannot be written")
+	}
+}
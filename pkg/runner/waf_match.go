@@ -0,0 +1,151 @@
+package runner
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+// MatchTargetInACL finds descriptors of IP sets which match target, and the action applied to target
+// Rules are evaluated in priority order, and the first ALLOW or BLOCK rule which contains all addresses of target decides the action.
+// Rules with conditions other than IP sets are not evaluated, so their matches do not decide the action.
+func MatchTargetInACL(target string, info *schema.WebACL) schema.IPCheckResult {
+	result := schema.IPCheckResult{
+		IP:     target,
+		Action: fmt.Sprintf("%s (default)", info.DefaultAction),
+	}
+
+	rules := append([]schema.ACLRule{}, info.Rules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+
+	decided := false
+	for _, rule := range rules {
+		decisive := isIPOnlyRule(rule)
+		for _, ds := range rule.IPDataSet {
+			// address in negated IP set does not match the rule
+			if ds.Negated {
+				continue
+			}
+
+			for _, descriptor := range ds.IPList {
+				match, err := MatchCIDR(target, descriptor)
+				if err != nil {
+					logrus.Warnf("descriptor of IP set %s is ignored: %s", ds.ID, err.Error())
+					continue
+				}
+
+				if len(match) == 0 {
+					continue
+				}
+
+				result.Matches = append(result.Matches, schema.IPMatch{
+					RuleID:     rule.RuleID,
+					Priority:   rule.Priority,
+					RuleAction: rule.ActionType,
+					IPSetID:    ds.ID,
+					Descriptor: descriptor,
+					Match:      match,
+				})
+
+				if !result.Result {
+					result.Result = true
+					result.IPSetID = ds.ID
+				}
+
+				if !decided && decisive && match != constants.OverlapMatch && isTerminatingAction(rule.ActionType) {
+					decided = true
+					result.RuleID = rule.RuleID
+					result.Action = rule.ActionType
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// isIPOnlyRule checks if rule is decided only by IP sets, without rate limit or negated IP sets
+// IP sets combined with AND are not decisive, because a match of one IP set does not match the rule.
+func isIPOnlyRule(rule schema.ACLRule) bool {
+	if rule.RateLimit > 0 {
+		return false
+	}
+
+	for _, condition := range rule.Conditions {
+		if condition.Type != waf.PredicateTypeIpmatch || condition.Negated {
+			return false
+		}
+
+		if tools.IsStringInArray(constants.AndOperator, strings.Split(condition.Operator, " > ")) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchCIDR compares query with descriptor of IP set, and returns empty string if they do not match
+func MatchCIDR(query, descriptor string) (string, error) {
+	q, err := parseCIDR(query)
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	d, err := parseCIDR(descriptor)
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	qOnes, qBits := q.Mask.Size()
+	dOnes, dBits := d.Mask.Size()
+
+	// IPv4 and IPv6 never match
+	if qBits != dBits {
+		return constants.EmptyString, nil
+	}
+
+	switch {
+	case qOnes == dOnes && q.IP.Equal(d.IP):
+		return constants.ExactMatch, nil
+	case dOnes <= qOnes && d.Contains(q.IP):
+		return constants.ContainedMatch, nil
+	case qOnes < dOnes && q.Contains(d.IP):
+		return constants.OverlapMatch, nil
+	}
+
+	return constants.EmptyString, nil
+}
+
+// parseCIDR parses IPv4 or IPv6 address with optional prefix length
+func parseCIDR(address string) (*net.IPNet, error) {
+	address = strings.TrimSpace(address)
+	if !strings.Contains(address, "/") {
+		if strings.Contains(address, ":") {
+			address += "/128"
+		} else {
+			address += "/32"
+		}
+	}
+
+	_, ipNet, err := net.ParseCIDR(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return ipNet, nil
+}
+
+// isTerminatingAction checks if action stops evaluation of other rules
+func isTerminatingAction(action string) bool {
+	return action == waf.WafActionTypeAllow || action == waf.WafActionTypeBlock
+}
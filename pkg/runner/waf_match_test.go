@@ -0,0 +1,161 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestMatchCIDR(t *testing.T) {
+	testData := []struct {
+		query      string
+		descriptor string
+		expected   string
+	}{
+		{query: "1.2.3.4/32", descriptor: "1.2.3.4/32", expected: "exact"},
+		{query: "1.2.3.4/32", descriptor: "1.2.0.0/16", expected: "contained"},
+		{query: "1.2.0.0/16", descriptor: "1.2.3.4/32", expected: "overlaps"},
+		{query: "1.3.0.0/16", descriptor: "1.2.0.0/16", expected: ""},
+		{query: "2001:db8::1", descriptor: "2001:db8::/32", expected: "contained"},
+		{query: "2001:db8::/32", descriptor: "2001:0db8:0000::/32", expected: "exact"},
+		{query: "1.2.3.4/32", descriptor: "::/0", expected: ""},
+	}
+
+	for _, td := range testData {
+		output, err := MatchCIDR(td.query, td.descriptor)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if output != td.expected {
+			t.Errorf("%s in %s: expected %q, output %q", td.query, td.descriptor, td.expected, output)
+		}
+	}
+
+	if _, err := MatchCIDR("1.2.3.4", "wrong"); err == nil {
+		t.Errorf("wrong descriptor should return error")
+	}
+}
+
+func TestMatchTargetInACL(t *testing.T) {
+	info := &schema.WebACL{
+		DefaultAction: "ALLOW",
+		Rules: []schema.ACLRule{
+			{
+				RuleID:     "block-office",
+				ActionType: "BLOCK",
+				Priority:   2,
+				IPDataSet:  []schema.IPDataSet{{ID: "office", IPList: []string{"1.2.0.0/16"}}},
+			},
+			{
+				RuleID:     "count-partner",
+				ActionType: "COUNT",
+				Priority:   1,
+				IPDataSet:  []schema.IPDataSet{{ID: "partner", IPList: []string{"1.2.3.0/24"}}},
+			},
+		},
+	}
+
+	result := MatchTargetInACL("1.2.3.4/32", info)
+	if !result.Result || result.IPSetID != "partner" || len(result.Matches) != 2 {
+		t.Errorf("wrong matches: %+v", result)
+	}
+
+	if result.Action != "BLOCK" || result.RuleID != "block-office" {
+		t.Errorf("expected BLOCK by block-office, output %s by %s", result.Action, result.RuleID)
+	}
+
+	// rule which contains only part of addresses does not decide action
+	result = MatchTargetInACL("1.0.0.0/8", info)
+	if !result.Result || result.Action != "ALLOW (default)" || result.Matches[0].Match != "overlaps" {
+		t.Errorf("wrong result of overlapping query: %+v", result)
+	}
+
+	if result = MatchTargetInACL("2001:db8::1/128", info); result.Result {
+		t.Errorf("IPv6 address should not match: %+v", result)
+	}
+}
+
+func TestMatchTargetInACLWithConditions(t *testing.T) {
+	info := &schema.WebACL{
+		DefaultAction: "ALLOW",
+		Rules: []schema.ACLRule{
+			{
+				RuleID:     "block-admin",
+				ActionType: "BLOCK",
+				Priority:   1,
+				Conditions: []schema.RuleCondition{{Type: "IPMatch", DataID: "office"}, {Type: "ByteMatch", DataID: "admin"}},
+				IPDataSet:  []schema.IPDataSet{{ID: "office", IPList: []string{"1.2.0.0/16"}}},
+			},
+			{
+				RuleID:     "block-not-office",
+				ActionType: "BLOCK",
+				Priority:   2,
+				Conditions: []schema.RuleCondition{{Type: "IPMatch", DataID: "office", Negated: true}},
+				IPDataSet:  []schema.IPDataSet{{ID: "office", Negated: true, IPList: []string{"1.2.0.0/16"}}},
+			},
+			{
+				RuleID:     "rate-office",
+				ActionType: "BLOCK",
+				Priority:   3,
+				RateLimit:  2000,
+				Conditions: []schema.RuleCondition{{Type: "IPMatch", DataID: "office"}},
+				IPDataSet:  []schema.IPDataSet{{ID: "office", IPList: []string{"1.2.0.0/16"}}},
+			},
+		},
+	}
+
+	result := MatchTargetInACL("1.2.3.4/32", info)
+	if result.Action != "ALLOW (default)" || len(result.RuleID) > 0 {
+		t.Errorf("rules with other conditions should not decide action: %+v", result)
+	}
+
+	if len(result.Matches) != 2 || result.Matches[0].RuleID != "block-admin" || result.Matches[1].RuleID != "rate-office" {
+		t.Errorf("wrong matches: %+v", result.Matches)
+	}
+}
+
+func TestIsIPOnlyRule(t *testing.T) {
+	testData := []struct {
+		name       string
+		conditions []schema.RuleCondition
+		expected   bool
+	}{
+		{
+			name:       "single IP set",
+			conditions: []schema.RuleCondition{{Type: "IPMatch"}},
+			expected:   true,
+		},
+		{
+			name:       "OR of IP sets",
+			conditions: []schema.RuleCondition{{Type: "IPMatch", Operator: "OR"}, {Type: "IPMatch", Operator: "OR"}},
+			expected:   true,
+		},
+		{
+			name:       "AND of IP sets",
+			conditions: []schema.RuleCondition{{Type: "IPMatch", Operator: "AND"}, {Type: "IPMatch", Operator: "AND"}},
+			expected:   false,
+		},
+		{
+			name:       "OR in AND",
+			conditions: []schema.RuleCondition{{Type: "IPMatch", Operator: "AND > OR"}, {Type: "IPMatch", Operator: "AND > OR"}},
+			expected:   false,
+		},
+		{
+			name:       "OR of IP set and geo",
+			conditions: []schema.RuleCondition{{Type: "IPMatch", Operator: "OR"}, {Type: "GeoMatch", Operator: "OR"}},
+			expected:   false,
+		},
+		{
+			name:       "AND of IP set and geo",
+			conditions: []schema.RuleCondition{{Type: "IPMatch", Operator: "AND"}, {Type: "GeoMatch", Operator: "AND"}},
+			expected:   false,
+		},
+	}
+
+	for _, td := range testData {
+		if output := isIPOnlyRule(schema.ACLRule{Conditions: td.conditions}); output != td.expected {
+			t.Errorf("%s: expected %t, output %t", td.name, td.expected, output)
+		}
+	}
+}
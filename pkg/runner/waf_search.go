@@ -0,0 +1,217 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/aws"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+// aclSearchResult is the result of searching IP addresses in a web ACL
+type aclSearchResult struct {
+	acl     schema.WebACLSummary
+	results []schema.IPCheckResult
+	err     error
+}
+
+// SearchIPInAllACLs searches IP addresses in all web ACLs and prints matrix of IP addresses and web ACLs
+func (r Runner) SearchIPInAllACLs(ctx context.Context, out io.Writer, targets []string) error {
+	version, scope, err := r.getWafOptions()
+	if err != nil {
+		return err
+	}
+
+	ACLs, err := r.AWSClient.ListWebACLs(version, scope)
+	if err != nil {
+		return err
+	}
+
+	if len(ACLs) == 0 {
+		return fmt.Errorf("no web ACL exists")
+	}
+
+	// requests of all web ACLs share a limiter, so that at most --concurrency requests are in flight
+	limiter := aws.NewWafLimiter(r.Flag.Concurrency)
+	describe := func(acl schema.WebACLSummary) (*schema.WebACL, error) {
+		var info *schema.WebACL
+		err := retryThrottling(ctx, constants.ThrottleRetryInterval, func() error {
+			var err error
+			info, err = r.AWSClient.DescribeWebACL(acl, limiter)
+			return err
+		})
+		return info, err
+	}
+
+	results := searchWebACLs(ACLs, targets, r.Flag.Concurrency, describe)
+	if err := printIPMatrix(out, targets, results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			logrus.Errorf("failed to describe web ACL %s: %s", result.acl.Name, result.err.Error())
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to describe %d web ACLs", failed)
+	}
+
+	return nil
+}
+
+// searchWebACLs describes web ACLs with workers, and results are in the order of web ACLs
+func searchWebACLs(ACLs []schema.WebACLSummary, targets []string, concurrency int, describe func(schema.WebACLSummary) (*schema.WebACL, error)) []aclSearchResult {
+	if concurrency <= 0 {
+		concurrency = constants.DefaultWafConcurrency
+	}
+
+	results := make([]aclSearchResult, len(ACLs))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx].acl = ACLs[idx]
+
+				info, err := describe(ACLs[idx])
+				if err != nil {
+					results[idx].err = err
+					continue
+				}
+
+				for _, target := range targets {
+					results[idx].results = append(results[idx].results, MatchTargetInACL(target, info))
+				}
+			}
+		}()
+	}
+
+	for i := range ACLs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// retryThrottling retries function with exponential backoff while AWS API is throttled
+// Waiting is stopped when context is cancelled.
+func retryThrottling(ctx context.Context, interval time.Duration, fn func() error) error {
+	for retry := 0; ; retry++ {
+		err := fn()
+		if err == nil || !aws.IsThrottlingError(err) || retry >= constants.MaxThrottleRetry {
+			return err
+		}
+
+		logrus.Debugf("request is throttled, retrying after %s", interval)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+}
+
+// printIPMatrix prints actions applied to each IP address by each web ACL, and matches of IP sets
+func printIPMatrix(out io.Writer, targets []string, results []aclSearchResult) error {
+	w := tabwriter.NewWriter(out, 0, 5, 3, ' ', tabwriter.TabIndent)
+
+	header := []string{"IP"}
+	for _, result := range results {
+		header = append(header, fmt.Sprintf("%s [%s]", result.acl.Name, aws.GetWebACLLabel(result.acl)))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for i, target := range targets {
+		row := []string{target}
+		for _, result := range results {
+			switch {
+			case result.err != nil:
+				row = append(row, "ERROR")
+			case len(result.results[i].RuleID) > 0:
+				row = append(row, fmt.Sprintf("%s (%s)", result.results[i].Action, result.results[i].RuleID))
+			default:
+				row = append(row, result.results[i].Action)
+			}
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "IP\tACL\tRULE\tIP SET\tDESCRIPTOR\tMATCH\tRULE ACTION")
+	for i, target := range targets {
+		for _, result := range results {
+			if result.err != nil {
+				continue
+			}
+
+			for _, match := range result.results[i].Matches {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", target, result.acl.Name, match.RuleID, match.IPSetID, match.Descriptor, match.Match, match.RuleAction)
+			}
+		}
+	}
+
+	return w.Flush()
+}
+
+// getHasIPTargets returns IP addresses in arguments and --file, '-' reads them from stdin
+func (r Runner) getHasIPTargets(args []string) ([]string, error) {
+	targets := append([]string{}, args...)
+	if len(r.Flag.File) == 0 {
+		return targets, nil
+	}
+
+	var in io.Reader = os.Stdin
+	if r.Flag.File != "-" {
+		f, err := os.Open(r.Flag.File)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	fromFile, err := readTargets(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(targets, fromFile...), nil
+}
+
+// readTargets reads IP addresses separated by spaces, commas or new lines, and lines starting with # are ignored
+func readTargets(in io.Reader) ([]string, error) {
+	var targets []string
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		targets = append(targets, strings.FieldsFunc(line, func(c rune) bool {
+			return c == ',' || c == ' ' || c == '\t'
+		})...)
+	}
+
+	return targets, scanner.Err()
+}
@@ -0,0 +1,115 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+
+	"github.com/DevopsArtFactory/act/pkg/builder"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestSearchWebACLs(t *testing.T) {
+	ACLs := []schema.WebACLSummary{{Name: "first"}, {Name: "broken"}, {Name: "third"}}
+	describe := func(acl schema.WebACLSummary) (*schema.WebACL, error) {
+		if acl.Name == "broken" {
+			return nil, errors.New("access denied")
+		}
+
+		return &schema.WebACL{
+			Name:          acl.Name,
+			DefaultAction: "ALLOW",
+			Rules: []schema.ACLRule{
+				{RuleID: "block", ActionType: "BLOCK", IPDataSet: []schema.IPDataSet{{ID: acl.Name, IPList: []string{"1.2.0.0/16"}}}},
+			},
+		}, nil
+	}
+
+	targets := []string{"1.2.3.4/32", "5.6.7.8/32"}
+	results := searchWebACLs(ACLs, targets, 2, describe)
+	if len(results) != 3 || results[2].acl.Name != "third" || results[1].err == nil {
+		t.Fatalf("wrong results: %+v", results)
+	}
+
+	if results[0].results[0].Action != "BLOCK" || results[0].results[1].Action != "ALLOW (default)" {
+		t.Errorf("wrong actions: %+v", results[0].results)
+	}
+
+	var out bytes.Buffer
+	if err := printIPMatrix(&out, targets, results); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), "BLOCK (block)") || !strings.Contains(out.String(), "ERROR") {
+		t.Errorf("wrong matrix:\n%s", out.String())
+	}
+}
+
+func TestRetryThrottling(t *testing.T) {
+	calls := 0
+	err := retryThrottling(context.Background(), 0, func() error {
+		calls++
+		if calls < 3 {
+			return awserr.New("ThrottlingException", "rate exceeded", nil)
+		}
+		return nil
+	})
+
+	if err != nil || calls != 3 {
+		t.Errorf("expected 3 calls without error, output %d calls, %v", calls, err)
+	}
+
+	calls = 0
+	if err := retryThrottling(context.Background(), 0, func() error {
+		calls++
+		return errors.New("access denied")
+	}); err == nil || calls != 1 {
+		t.Errorf("error without throttling should not be retried: %d calls", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls = 0
+	err = retryThrottling(ctx, time.Hour, func() error {
+		calls++
+		return awserr.New("ThrottlingException", "rate exceeded", nil)
+	})
+
+	if err != context.Canceled || calls != 1 {
+		t.Errorf("retry should stop when context is cancelled: %d calls, %v", calls, err)
+	}
+}
+
+func TestHasIPFromStdinNeedsACL(t *testing.T) {
+	r := Runner{Flag: &builder.Flags{File: "-"}}
+	if err := r.HasIP(context.Background(), ioutil.Discard, nil); err == nil {
+		t.Errorf("error should be returned without --all or --web-acl")
+	}
+}
+
+func TestReadTargets(t *testing.T) {
+	input := `# customers
+1.2.3.4, 5.6.7.8
+2001:db8::1
+
+10.0.0.0/8	172.16.0.0/12
+`
+
+	targets, err := readTargets(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"1.2.3.4", "5.6.7.8", "2001:db8::1", "10.0.0.0/8", "172.16.0.0/12"}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("expected %v, output %v", expected, targets)
+	}
+}
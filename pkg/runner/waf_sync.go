@@ -0,0 +1,348 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/DevopsArtFactory/act/pkg/color"
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+// csvColumns is the header of IP set file in CSV
+var csvColumns = []string{"name", "id", "version", "scope", "address"}
+
+// SyncIPSets makes addresses of IP sets the same as the file
+// Addresses which are not in the file are kept if --prune=false.
+// IP set is not pruned to empty unless --allow-empty is set.
+func (r Runner) SyncIPSets(out io.Writer) error {
+	if len(r.Flag.File) == 0 {
+		return errors.New("file of IP sets should be specified with --file")
+	}
+
+	format, err := getIPSetFileFormat(r.Flag.File, r.Flag.FileFormat)
+	if err != nil {
+		return err
+	}
+
+	b, err := ioutil.ReadFile(r.Flag.File)
+	if err != nil {
+		return err
+	}
+
+	file, err := parseIPSetFile(b, format)
+	if err != nil {
+		return err
+	}
+
+	version, scope, err := r.getWafOptions()
+	if err != nil {
+		return err
+	}
+
+	var changes []ipSetChange
+	synced := map[string]string{}
+	for _, entry := range file.IPSets {
+		parsed, err := ParseTargetList(entry.Addresses)
+		if err != nil {
+			return err
+		}
+		desired := normalizeTargetList(parsed)
+
+		if len(desired) > constants.MaxIPSetAddresses {
+			return fmt.Errorf("IP set %s cannot have more than %d addresses: %d", getIPSetEntryTarget(entry), constants.MaxIPSetAddresses, len(desired))
+		}
+
+		ipSet, err := r.findIPSetEntry(entry, version, scope)
+		if err != nil {
+			return err
+		}
+
+		key := getIPSetKey(ipSet)
+		if previous, ok := synced[key]; ok {
+			return fmt.Errorf("IP set %s is specified more than once in the file: %s, %s", ipSet.Name, previous, getIPSetEntryTarget(entry))
+		}
+		synced[key] = getIPSetEntryTarget(entry)
+
+		current, err := r.AWSClient.GetIPSetAddresses(ipSet)
+		if err != nil {
+			return err
+		}
+
+		change, err := planIPSetSync(ipSet, current, desired, r.Flag.Prune, r.Flag.AllowEmpty)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, change)
+	}
+
+	return r.applyIPSetChanges(out, changes)
+}
+
+// planIPSetSync returns change which makes current addresses the same as desired addresses
+func planIPSetSync(ipSet schema.IPSetSummary, current, desired []string, prune, allowEmpty bool) (ipSetChange, error) {
+	change := ipSetChange{
+		ipSet:   ipSet,
+		current: current,
+		toAdd:   diffAddresses(desired, current),
+	}
+
+	if prune {
+		change.toRemove = diffAddresses(current, desired)
+		if len(desired) == 0 && len(change.toRemove) > 0 && !allowEmpty {
+			return change, fmt.Errorf("IP set %s would have no address after sync, use --allow-empty to remove all addresses", ipSet.Name)
+		}
+	}
+
+	return change, nil
+}
+
+// ExportIPSets writes addresses of IP sets in the format of sync file
+func (r Runner) ExportIPSets(out io.Writer) error {
+	version, scope, err := r.getWafOptions()
+	if err != nil {
+		return err
+	}
+
+	format, err := getIPSetFileFormat(r.Flag.File, r.Flag.FileFormat)
+	if err != nil {
+		return err
+	}
+
+	var ipSets []schema.IPSetSummary
+	if len(r.Flag.IPSet) > 0 {
+		ipSet, err := r.AWSClient.FindIPSet(r.Flag.IPSet, version, scope)
+		if err != nil {
+			return err
+		}
+		ipSets = append(ipSets, ipSet)
+	} else {
+		ipSets, err = r.AWSClient.ListIPSets(version, scope)
+		if err != nil {
+			return err
+		}
+	}
+
+	var file schema.IPSetFile
+	for _, ipSet := range ipSets {
+		addresses, err := r.AWSClient.GetIPSetAddresses(ipSet)
+		if err != nil {
+			return err
+		}
+
+		file.IPSets = append(file.IPSets, schema.IPSetEntry{
+			Name:      ipSet.Name,
+			ID:        ipSet.ID,
+			Version:   ipSet.Version,
+			Scope:     ipSet.Scope,
+			Addresses: addresses,
+		})
+	}
+
+	b, err := formatIPSetFile(file, format)
+	if err != nil {
+		return err
+	}
+
+	if len(r.Flag.File) == 0 {
+		_, err = out.Write(b)
+		return err
+	}
+
+	if err := ioutil.WriteFile(r.Flag.File, b, 0644); err != nil {
+		return err
+	}
+	color.Green.Fprintf(out, "%d IP sets are exported to %s\n", len(file.IPSets), r.Flag.File)
+
+	return nil
+}
+
+// findIPSetEntry finds IP set of entry, version and scope in the entry have priority over flags
+func (r Runner) findIPSetEntry(entry schema.IPSetEntry, version, scope string) (schema.IPSetSummary, error) {
+	target := getIPSetEntryTarget(entry)
+	if len(target) == 0 {
+		return schema.IPSetSummary{}, errors.New("name or id of IP set should be specified in the file")
+	}
+
+	if len(entry.Version) > 0 {
+		version = strings.ToLower(entry.Version)
+	}
+
+	if len(entry.Scope) > 0 {
+		scope = strings.ToUpper(entry.Scope)
+	}
+
+	return r.AWSClient.FindIPSet(target, version, scope)
+}
+
+// getIPSetKey returns key which identifies IP set across versions and scopes
+func getIPSetKey(ipSet schema.IPSetSummary) string {
+	return strings.Join([]string{ipSet.Version, ipSet.Scope, ipSet.ID}, "/")
+}
+
+// getIPSetEntryTarget returns ID of entry, or name if ID is empty
+func getIPSetEntryTarget(entry schema.IPSetEntry) string {
+	if len(entry.ID) > 0 {
+		return entry.ID
+	}
+	return entry.Name
+}
+
+// getIPSetFileFormat returns format of IP set file, which is detected from extension if not specified
+func getIPSetFileFormat(path, format string) (string, error) {
+	if len(format) > 0 {
+		format = strings.ToLower(format)
+		if !tools.IsStringInArray(format, []string{constants.YAMLFormat, constants.CSVFormat}) {
+			return constants.EmptyString, fmt.Errorf("file format should be yaml or csv: %s", format)
+		}
+		return format, nil
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == ".csv" {
+		return constants.CSVFormat, nil
+	}
+
+	return constants.YAMLFormat, nil
+}
+
+// parseIPSetFile parses IP set file in YAML or CSV
+// Unknown fields and duplicate entries are rejected, so that typo does not change IP set silently.
+func parseIPSetFile(b []byte, format string) (*schema.IPSetFile, error) {
+	if format == constants.CSVFormat {
+		return parseIPSetCSV(b)
+	}
+
+	var file schema.IPSetFile
+	if err := yaml.UnmarshalStrict(b, &file); err != nil {
+		return nil, err
+	}
+
+	entries := map[string]bool{}
+	for _, entry := range file.IPSets {
+		key := getIPSetEntryKey(entry)
+		if entries[key] {
+			return nil, fmt.Errorf("IP set %s is specified more than once in the file", getIPSetEntryTarget(entry))
+		}
+		entries[key] = true
+
+		if err := checkDuplicateAddresses(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return &file, nil
+}
+
+// getIPSetEntryKey returns key of entry in the file
+func getIPSetEntryKey(entry schema.IPSetEntry) string {
+	return strings.Join([]string{entry.Name, entry.ID, strings.ToLower(entry.Version), strings.ToUpper(entry.Scope)}, ",")
+}
+
+// checkDuplicateAddresses returns error if the same address is listed twice in entry
+func checkDuplicateAddresses(entry schema.IPSetEntry) error {
+	addresses := map[string]bool{}
+	for _, address := range entry.Addresses {
+		if addresses[address] {
+			return fmt.Errorf("address %s is duplicated in IP set %s", address, getIPSetEntryTarget(entry))
+		}
+		addresses[address] = true
+	}
+	return nil
+}
+
+// parseIPSetCSV parses IP set file in CSV, which has a row per address
+func parseIPSetCSV(b []byte) (*schema.IPSetFile, error) {
+	records, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return &schema.IPSetFile{}, nil
+	}
+
+	columns := map[string]int{}
+	for i, column := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	if _, ok := columns["address"]; !ok {
+		return nil, fmt.Errorf("CSV header should have columns of %s", strings.Join(csvColumns, ","))
+	}
+
+	get := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return constants.EmptyString
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var file schema.IPSetFile
+	index := map[string]int{}
+	for _, record := range records[1:] {
+		entry := schema.IPSetEntry{
+			Name:    get(record, "name"),
+			ID:      get(record, "id"),
+			Version: get(record, "version"),
+			Scope:   get(record, "scope"),
+		}
+
+		key := getIPSetEntryKey(entry)
+		if _, ok := index[key]; !ok {
+			index[key] = len(file.IPSets)
+			file.IPSets = append(file.IPSets, entry)
+		}
+
+		// IP set without address has a row with empty address
+		if address := get(record, "address"); len(address) > 0 {
+			file.IPSets[index[key]].Addresses = append(file.IPSets[index[key]].Addresses, address)
+		}
+	}
+
+	for _, entry := range file.IPSets {
+		if err := checkDuplicateAddresses(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return &file, nil
+}
+
+// formatIPSetFile formats IP set file in YAML or CSV
+func formatIPSetFile(file schema.IPSetFile, format string) ([]byte, error) {
+	if format != constants.CSVFormat {
+		return yaml.Marshal(file)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvColumns); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range file.IPSets {
+		addresses := entry.Addresses
+		if len(addresses) == 0 {
+			addresses = []string{constants.EmptyString}
+		}
+
+		for _, address := range addresses {
+			if err := w.Write([]string{entry.Name, entry.ID, entry.Version, entry.Scope, address}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
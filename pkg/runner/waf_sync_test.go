@@ -0,0 +1,157 @@
+package runner
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+func TestParseIPSetCSV(t *testing.T) {
+	b := []byte(`name,scope,address
+office,REGIONAL,10.0.0.0/8
+partner,,203.0.113.10
+office,REGIONAL,172.16.0.0/12
+empty,,
+`)
+
+	file, err := parseIPSetCSV(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []schema.IPSetEntry{
+		{Name: "office", Scope: "REGIONAL", Addresses: []string{"10.0.0.0/8", "172.16.0.0/12"}},
+		{Name: "partner", Addresses: []string{"203.0.113.10"}},
+		{Name: "empty"},
+	}
+
+	if !reflect.DeepEqual(file.IPSets, expected) {
+		t.Errorf("expected %v, output %v", expected, file.IPSets)
+	}
+
+	if _, err := parseIPSetCSV([]byte("name,cidr\noffice,10.0.0.0/8\n")); err == nil {
+		t.Errorf("CSV without address column should return error")
+	}
+}
+
+func TestFormatIPSetFile(t *testing.T) {
+	file := schema.IPSetFile{
+		IPSets: []schema.IPSetEntry{
+			{Name: "office", ID: "1234", Version: "v2", Scope: "REGIONAL", Addresses: []string{"10.0.0.0/8", "2001:db8::/32"}},
+			{Name: "empty", ID: "5678", Version: "classic", Scope: "CLOUDFRONT"},
+		},
+	}
+
+	for _, format := range []string{"yaml", "csv"} {
+		b, err := formatIPSetFile(file, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parsed, err := parseIPSetFile(b, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// empty addresses can be parsed as either nil or empty slice
+		if fmt.Sprint(parsed.IPSets) != fmt.Sprint(file.IPSets) {
+			t.Errorf("%s: expected %v, output %v", format, file.IPSets, parsed.IPSets)
+		}
+	}
+}
+
+func TestGetIPSetFileFormat(t *testing.T) {
+	testData := []struct {
+		path     string
+		format   string
+		expected string
+	}{
+		{path: "allowlist.yaml", expected: "yaml"},
+		{path: "allowlist.CSV", expected: "csv"},
+		{path: "", format: "csv", expected: "csv"},
+		{path: "allowlist.csv", format: "YAML", expected: "yaml"},
+	}
+
+	for _, td := range testData {
+		output, err := getIPSetFileFormat(td.path, td.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if output != td.expected {
+			t.Errorf("expected %s, output %s", td.expected, output)
+		}
+	}
+
+	if _, err := getIPSetFileFormat("allowlist.json", "json"); err == nil {
+		t.Errorf("json format should return error")
+	}
+}
+
+func TestParseIPSetFileRejectsInvalidFile(t *testing.T) {
+	testData := []struct {
+		name   string
+		format string
+		file   string
+	}{
+		{
+			name:   "unknown field",
+			format: "yaml",
+			file:   "ip_sets:\n  - name: office\n    adresses:\n      - 10.0.0.0/8\n",
+		},
+		{
+			name:   "duplicate IP set",
+			format: "yaml",
+			file:   "ip_sets:\n  - name: office\n    addresses: [10.0.0.0/8]\n  - name: office\n    addresses: [172.16.0.0/12]\n",
+		},
+		{
+			name:   "duplicate address",
+			format: "yaml",
+			file:   "ip_sets:\n  - name: office\n    addresses: [10.0.0.0/8, 10.0.0.0/8]\n",
+		},
+		{
+			name:   "duplicate address in CSV",
+			format: "csv",
+			file:   "name,address\noffice,10.0.0.0/8\noffice,10.0.0.0/8\n",
+		},
+	}
+
+	for _, td := range testData {
+		if _, err := parseIPSetFile([]byte(td.file), td.format); err == nil {
+			t.Errorf("%s: error should be returned", td.name)
+		}
+	}
+}
+
+func TestPlanIPSetSync(t *testing.T) {
+	ipSet := schema.IPSetSummary{Name: "office"}
+	current := []string{"10.0.0.0/8"}
+
+	if _, err := planIPSetSync(ipSet, current, nil, true, false); err == nil {
+		t.Errorf("IP set should not be pruned to empty without --allow-empty")
+	}
+
+	change, err := planIPSetSync(ipSet, current, nil, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(change.toRemove, current) {
+		t.Errorf("expected %v, output %v", current, change.toRemove)
+	}
+
+	change, err = planIPSetSync(ipSet, current, nil, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(change.toRemove) != 0 {
+		t.Errorf("addresses should be kept without prune: %v", change.toRemove)
+	}
+
+	if _, err := planIPSetSync(ipSet, nil, nil, true, false); err != nil {
+		t.Errorf("empty IP set should be synced without --allow-empty: %v", err)
+	}
+}
@@ -1,22 +1,49 @@
 package schema
 
+import "time"
+
 type Config struct {
-	Profile     string              `yaml:"profile"`
-	Name        string              `yaml:"name"`
-	Duration    int                 `yaml:"duration"`
-	Alias       map[string]string   `yaml:"alias"`
-	AssumeRoles map[string]string   `yaml:"assume_roles"`
-	Databases   map[string][]string `yaml:"databases"`
-	Maintenance struct {
-		Message string `yaml:"message"`
-		Arns    []struct {
-			LoadbalancerArn string `yaml:"loadbalancer_arn"`
-		}
+	Profile            string               `yaml:"profile"`
+	Name               string               `yaml:"name"`
+	Duration           int                  `yaml:"duration"`
+	MFASerial          string               `yaml:"mfa_serial"`
+	AccountID          string               `yaml:"account_id"`
+	MFADeviceName      string               `yaml:"mfa_device_name"`
+	Partition          string               `yaml:"partition"`
+	MFASessionDuration int                  `yaml:"mfa_session_duration"`
+	FederationEndpoint string               `yaml:"federation_endpoint"`
+	Alias              map[string]string    `yaml:"alias"`
+	AssumeRoles        map[string]string    `yaml:"assume_roles"`
+	RoleChains         map[string][]RoleHop `yaml:"role_chains"`
+	Databases          map[string][]string  `yaml:"databases"`
+	Maintenance        struct {
+		Message  string                    `yaml:"message"`
+		Arns     []MaintenanceTarget       `yaml:"arns"`
+		Bypass   MaintenanceBypass         `yaml:"bypass"`
+		Response MaintenanceResponseConfig `yaml:"response"`
 	} `yaml:"maintenance"`
 	Loadtest struct {
-		RDS []string `yaml:"rds"`
-		ASG []string `yaml:"asg"`
+		Env       string                 `yaml:"env"`
+		RDS       []string               `yaml:"rds"`
+		ASG       []string               `yaml:"asg"`
+		Snapshot  string                 `yaml:"snapshot"`
+		StateFile string                 `yaml:"state_file"`
+		Overrides map[string]ASGCapacity `yaml:"overrides"`
 	} `yaml:"loadtest"`
+	Vault struct {
+		Type string `yaml:"type"`
+		Path string `yaml:"path"`
+	} `yaml:"vault"`
+	Clipboard struct {
+		Backend    string `yaml:"backend"`
+		ClearAfter int    `yaml:"clear_after"`
+	} `yaml:"clipboard"`
+}
+
+type RoleHop struct {
+	RoleArn     string `yaml:"role_arn"`
+	ExternalID  string `yaml:"external_id"`
+	SessionName string `yaml:"session_name"`
 }
 
 type AWSConfig struct {
@@ -25,10 +52,53 @@ type AWSConfig struct {
 	CreateDate      string
 }
 
+type WebACLSummary struct {
+	ID      string
+	Name    string
+	ARN     string
+	Version string
+	Scope   string
+}
+
+type IPSetSummary struct {
+	ID      string
+	Name    string
+	ARN     string
+	Version string
+	Scope   string
+}
+
+type IPSetFile struct {
+	IPSets []IPSetEntry `yaml:"ip_sets"`
+}
+
+type IPSetEntry struct {
+	Name      string   `yaml:"name,omitempty"`
+	ID        string   `yaml:"id,omitempty"`
+	Version   string   `yaml:"version,omitempty"`
+	Scope     string   `yaml:"scope,omitempty"`
+	Addresses []string `yaml:"addresses"`
+}
+
+type WafAuditRecord struct {
+	Time      time.Time `json:"time"`
+	Caller    string    `json:"caller"`
+	Account   string    `json:"account"`
+	IPSetID   string    `json:"ip_set_id"`
+	IPSetName string    `json:"ip_set_name"`
+	Version   string    `json:"version"`
+	Scope     string    `json:"scope"`
+	Added     []string  `json:"added,omitempty"`
+	Removed   []string  `json:"removed,omitempty"`
+}
+
 type WebACL struct {
-	ID    string
-	Name  string
-	Rules []ACLRule
+	ID            string
+	Name          string
+	Version       string
+	Scope         string
+	DefaultAction string
+	Rules         []ACLRule
 }
 
 type ACLRule struct {
@@ -36,18 +106,95 @@ type ACLRule struct {
 	ActionType string
 	Priority   int64
 	RuleID     string
+	Name       string
+	RateKey    string
+	RateLimit  int64
+	Conditions []RuleCondition
 	IPDataSet  []IPDataSet
 }
 
+// RuleCondition is a predicate of WAF Classic or a statement of WAFv2 in rule
+// Operator is the path of logical statements which contain the condition, e.g. "OR > AND", and empty if it is not nested.
+type RuleCondition struct {
+	Type     string
+	DataID   string
+	Name     string
+	Negated  bool
+	Operator string
+	Matches  []string
+}
+
 type IPDataSet struct {
-	ID     string
-	IPList []string
+	ID      string
+	Name    string
+	Negated bool
+	IPList  []string
 }
 
 type IPCheckResult struct {
 	IP      string
 	IPSetID string
 	Result  bool
+	RuleID  string
+	Action  string
+	Matches []IPMatch
+}
+
+type IPMatch struct {
+	RuleID     string
+	Priority   int64
+	RuleAction string
+	IPSetID    string
+	Descriptor string
+	Match      string
+}
+
+type MaintenanceTarget struct {
+	LoadbalancerArn string                    `yaml:"loadbalancer_arn"`
+	Response        MaintenanceResponseConfig `yaml:"response"`
+	Hosts           []string                  `yaml:"hosts"`
+	Paths           []string                  `yaml:"paths"`
+}
+
+type MaintenanceResponseConfig struct {
+	ContentType  string `yaml:"content_type"`
+	StatusCode   int    `yaml:"status_code"`
+	Template     string `yaml:"template"`
+	TemplateFile string `yaml:"template_file"`
+}
+
+type MaintenanceResponse struct {
+	ContentType string
+	StatusCode  string
+	Body        string
+	Hosts       []string
+	Paths       []string
+}
+
+type MaintenanceWindow struct {
+	Message   string
+	Start     time.Time
+	End       time.Time
+	Timestamp int64
+}
+
+type MaintenanceState struct {
+	Profile   string    `json:"profile"`
+	Env       string    `json:"env"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Applied   bool      `json:"applied"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type MaintenanceBypass struct {
+	CIDRs   []string       `yaml:"cidrs"`
+	Headers []BypassHeader `yaml:"headers"`
+}
+
+type BypassHeader struct {
+	Name   string   `yaml:"name"`
+	Values []string `yaml:"values"`
 }
 
 type MaintenanceMessage struct {
@@ -55,3 +202,33 @@ type MaintenanceMessage struct {
 	Code      int64  `json:"code"`
 	Message   string `json:"message"`
 }
+
+type CredentialsOutput struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	SessionToken    string `json:"SessionToken"`
+	Expiration      string `json:"Expiration,omitempty"`
+	Region          string `json:"Region,omitempty"`
+}
+
+type CredentialProcessOutput struct {
+	Version         int    `json:"Version"`
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	SessionToken    string `json:"SessionToken"`
+	Expiration      string `json:"Expiration"`
+}
+
+type ASGCapacity struct {
+	MinSize            int64    `yaml:"min" json:"min"`
+	DesiredCapacity    int64    `yaml:"desired" json:"desired"`
+	MaxSize            int64    `yaml:"max" json:"max"`
+	SuspendedProcesses []string `yaml:"suspended_processes" json:"suspended"`
+}
+
+type LoadtestStatus struct {
+	Type   string
+	Name   string
+	Status string
+	Ready  bool
+}
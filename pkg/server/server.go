@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+)
+
+// ECSCredentialsPath is the path for AWS_CONTAINER_CREDENTIALS_FULL_URI
+const ECSCredentialsPath = "/creds"
+
+// Server serves assume role credentials with ECS compatible endpoint
+// IMDS is not emulated, because its clients cannot be authorized with the bearer token.
+type Server struct {
+	Addr    string
+	Token   string
+	RoleArn string
+
+	provider func() (*sts.Credentials, error)
+
+	mu    sync.Mutex
+	creds *sts.Credentials
+}
+
+// ecsCredentials is the response format of ECS credentials endpoint
+type ecsCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	Token           string `json:"Token"`
+	Expiration      string `json:"Expiration"`
+	RoleArn         string `json:"RoleArn"`
+}
+
+// New creates credential server
+func New(addr, token, roleArn string, provider func() (*sts.Credentials, error)) (*Server, error) {
+	if len(token) == 0 {
+		return nil, errors.New("token of credential server should not be empty")
+	}
+
+	return &Server{
+		Addr:     addr,
+		Token:    token,
+		RoleArn:  roleArn,
+		provider: provider,
+	}, nil
+}
+
+// GenerateToken creates random token for authorization
+func GenerateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return constants.EmptyString, err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Run serves credentials until context is cancelled
+func (s *Server) Run(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:    s.Addr,
+		Handler: s.Handler(),
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		logrus.Infof("shutting down credential server")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// Handler returns handler of credential server
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ECSCredentialsPath, s.handleECSCredentials)
+
+	return logRequest(s.checkHost(mux))
+}
+
+// handleECSCredentials returns credentials in the format of ECS credentials endpoint
+func (s *Server) handleECSCredentials(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(auth), []byte(s.Token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	creds, err := s.credentials()
+	if err != nil {
+		logrus.Errorf(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, ecsCredentials{
+		AccessKeyID:     *creds.AccessKeyId,
+		SecretAccessKey: *creds.SecretAccessKey,
+		Token:           *creds.SessionToken,
+		Expiration:      creds.Expiration.UTC().Format(time.RFC3339),
+		RoleArn:         s.RoleArn,
+	})
+}
+
+// checkHost rejects requests whose Host is not the address of server, which prevents DNS rebinding from web pages
+func (s *Server) checkHost(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.isAllowedHost(r.Host) {
+			logrus.Warnf("request with Host %s is rejected", r.Host)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// isAllowedHost checks if host is loopback or the address of server, with the same port
+func (s *Server) isAllowedHost(host string) bool {
+	name, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return false
+	}
+
+	address, serverPort, err := net.SplitHostPort(s.Addr)
+	if err != nil || port != serverPort {
+		return false
+	}
+
+	if name == "localhost" || (len(address) > 0 && name == address) {
+		return true
+	}
+
+	ip := net.ParseIP(name)
+	return ip != nil && ip.IsLoopback()
+}
+
+// credentials returns current credentials, and refreshes them before expiration
+func (s *Server) credentials() (*sts.Credentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.creds != nil && time.Now().Add(constants.DefaultExpirationWindow).Before(*s.creds.Expiration) {
+		return s.creds, nil
+	}
+
+	logrus.Infof("refreshing credentials of %s", s.RoleArn)
+	creds, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+	s.creds = creds
+
+	return creds, nil
+}
+
+// writeJSON writes JSON response
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logrus.Errorf(err.Error())
+	}
+}
+
+// logRequest logs every request in debug level
+func logRequest(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logrus.Debugf("%s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+		h.ServeHTTP(w, r)
+	})
+}
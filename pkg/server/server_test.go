@@ -0,0 +1,119 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+func newTestServer(t *testing.T) (*Server, *int) {
+	called := 0
+	s, err := New("127.0.0.1:0", "token", "arn:aws:iam::123456789012:role/admin", func() (*sts.Credentials, error) {
+		called++
+		return &sts.Credentials{
+			AccessKeyId:     aws.String("ASIAEXAMPLE"),
+			SecretAccessKey: aws.String("secret"),
+			SessionToken:    aws.String("session"),
+			Expiration:      aws.Time(time.Now().Add(time.Hour)),
+		}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s, &called
+}
+
+func TestECSCredentials(t *testing.T) {
+	s, called := newTestServer(t)
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+	s.Addr = ts.Listener.Addr().String()
+
+	testData := []struct {
+		auth     string
+		expected int
+	}{
+		{auth: "", expected: http.StatusUnauthorized},
+		{auth: "Bearer wrong", expected: http.StatusUnauthorized},
+		{auth: "Bearer token", expected: http.StatusOK},
+		{auth: "token", expected: http.StatusOK},
+	}
+
+	for _, td := range testData {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+ECSCredentialsPath, nil)
+		req.Header.Set("Authorization", td.auth)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != td.expected {
+			t.Errorf("authorization: %s, expected: %d, output: %d", td.auth, td.expected, resp.StatusCode)
+		}
+	}
+
+	if *called != 1 {
+		t.Errorf("credentials should be reused until expiration, called: %d", *called)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	s, _ := newTestServer(t)
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+	s.Addr = ts.Listener.Addr().String()
+
+	_, port, _ := net.SplitHostPort(s.Addr)
+	testData := []struct {
+		host     string
+		expected int
+	}{
+		{host: s.Addr, expected: http.StatusOK},
+		{host: "localhost:" + port, expected: http.StatusOK},
+		{host: "[::1]:" + port, expected: http.StatusOK},
+		{host: "attacker.example.com:" + port, expected: http.StatusForbidden},
+		{host: "127.0.0.1:1", expected: http.StatusForbidden},
+		{host: "127.0.0.1", expected: http.StatusForbidden},
+	}
+
+	for _, td := range testData {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+ECSCredentialsPath, nil)
+		req.Host = td.host
+		req.Header.Set("Authorization", "Bearer token")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != td.expected {
+			t.Errorf("host: %s, expected: %d, output: %d", td.host, td.expected, resp.StatusCode)
+		}
+	}
+}
+
+func TestIMDSIsNotServed(t *testing.T) {
+	s, _ := newTestServer(t)
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+	s.Addr = ts.Listener.Addr().String()
+
+	req, _ := http.NewRequest(http.MethodPut, ts.URL+"/latest/api/token", nil)
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "21600")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("IMDS token should not be issued, output: %d", resp.StatusCode)
+	}
+}
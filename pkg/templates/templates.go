@@ -2,14 +2,33 @@ package templates
 
 const WafTemplate = `{{decorate "bold" "Name"}}:	{{ .Summary.Name }}
 {{decorate "bold" "ID"}}:	{{ .Summary.ID }}
+{{decorate "bold" "Version"}}:	{{ .Summary.Version }}
+{{decorate "bold" "Scope"}}:	{{ .Summary.Scope }}
+{{decorate "bold" "Default Action"}}:	{{ .Summary.DefaultAction }}
 
 {{decorate "rules" ""}}{{decorate "underline bold" "Rules"}}
 {{- if eq (len .Summary.Rules) 0 }}
  No rule exists
 {{- else }}
-ID	TYPE	ACTION	PRIORITY	DATESET COUNT
+ID	NAME	TYPE	ACTION	PRIORITY	CONDITIONS
 {{- range $rule := .Summary.Rules }}
-{{ $rule.RuleID }}	{{ $rule.Type }}	{{ $rule.ActionType }}	{{ $rule.Priority }}	{{ len $rule.IPDataSet }}
+{{ $rule.RuleID }}	{{ $rule.Name }}	{{ $rule.Type }}	{{ $rule.ActionType }}	{{ $rule.Priority }}	{{ len $rule.Conditions }}
+{{- end }}
+
+{{decorate "conditions" ""}}{{decorate "underline bold" "Rule Conditions"}}
+RULE	OPERATOR	CONDITION	NAME	MATCH
+{{- range $rule := .Summary.Rules }}
+{{- if gt $rule.RateLimit 0 }}
+{{ $rule.RuleID }}	-	RateBased	{{ $rule.RateKey }}	{{ $rule.RateLimit }} requests per 5 minutes
+{{- end }}
+{{- range $condition := $rule.Conditions }}
+{{- if eq (len $condition.Matches) 0 }}
+{{ $rule.RuleID }}	{{ if $condition.Operator }}{{ $condition.Operator }}{{ else }}-{{ end }}	{{ if $condition.Negated }}NOT {{ end }}{{ $condition.Type }}	{{ $condition.Name }}	-
+{{- end }}
+{{- range $match := $condition.Matches }}
+{{ $rule.RuleID }}	{{ if $condition.Operator }}{{ $condition.Operator }}{{ else }}-{{ end }}	{{ if $condition.Negated }}NOT {{ end }}{{ $condition.Type }}	{{ $condition.Name }}	{{ $match }}
+{{- end }}
+{{- end }}
 {{- end }}
 {{- end }}
 
@@ -20,8 +39,8 @@ ID	TYPE	ACTION	PRIORITY	DATESET COUNT
 {{- if eq (len $ipset.IPList) 0 }}
 No IP is registered
 {{- else }}
-ID	Count
-{{ $ipset.ID }}	{{ len $ipset.IPList }}
+ID	Name	Negated	Count
+{{ $ipset.ID }}	{{ $ipset.Name }}	{{ $ipset.Negated }}	{{ len $ipset.IPList }}
 {{- end }}
 {{- end }}
 {{- end }}
@@ -32,9 +51,17 @@ const IPSearchResultTemplate = `{{decorate "result" ""}}{{decorate "underline bo
 {{- if eq (len .Summary) 0 }}
  No result exists
 {{- else }}
-IP	IPSetID	Result
+IP	IPSetID	Result	Action	Rule
 {{- range $result := .Summary }}
-{{ $result.IP }}	{{ $result.IPSetID }}	{{ $result.Result }}
+{{ $result.IP }}	{{ $result.IPSetID }}	{{ $result.Result }}	{{ $result.Action }}	{{ $result.RuleID }}
+{{- end }}
+
+{{decorate "matches" ""}}{{decorate "underline bold" "Matches"}}
+IP	Match	Descriptor	IPSetID	Rule	Priority	Rule Action
+{{- range $result := .Summary }}
+{{- range $match := $result.Matches }}
+{{ $result.IP }}	{{ $match.Match }}	{{ $match.Descriptor }}	{{ $match.IPSetID }}	{{ $match.RuleID }}	{{ $match.Priority }}	{{ $match.RuleAction }}
+{{- end }}
 {{- end }}
 {{- end }}
 `
@@ -7,6 +7,8 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
@@ -40,31 +42,33 @@ func FileExists(filename string) bool {
 	return !info.IsDir()
 }
 
-// CopyToClipBoard copies token to clipboard
-func CopyToClipBoard(token string) error {
-	pbcopy := exec.Command("pbcopy")
-	in, _ := pbcopy.StdinPipe()
-
-	if err := pbcopy.Start(); err != nil {
-		return err
+// OpenBrowser opens URL with the default browser
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
 	}
 
-	if _, err := in.Write([]byte(token)); err != nil {
-		return err
-	}
+	return cmd.Start()
+}
 
-	if err := in.Close(); err != nil {
-		return err
+// ParseTime parses time in RFC3339 or `2006-01-02 15:04` of local timezone
+func ParseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
 	}
 
-	err := pbcopy.Wait()
+	t, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local)
 	if err != nil {
-		return err
+		return t, fmt.Errorf("time should be RFC3339 or `2006-01-02 15:04`: %s", value)
 	}
 
-	logrus.Info("Token is copied to clipboard.")
-
-	return nil
+	return t, nil
 }
 
 //Figure out if string is in array
@@ -86,6 +90,37 @@ func CreateFile(filePath string, writeData string) error {
 	return nil
 }
 
+// WriteFileAtomic writes data to temporary file in the same directory and renames it to filename,
+// so that the file is never left partially written
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	f, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), filename)
+}
+
 // AskContinue provides interactive terminal for users to answer if they continue process or not
 func AskContinue(msg string) error {
 	var answer string
@@ -140,7 +175,8 @@ func Ask(msg string, isSecret bool) (string, error) {
 			Message: msg,
 		}
 	}
-	survey.AskOne(prompt, &answer)
+	// prompt on stderr in order not to pollute output of command
+	survey.AskOne(prompt, &answer, survey.WithStdio(os.Stdin, os.Stderr, os.Stderr))
 
 	if len(answer) == 0 {
 		return answer, errors.New("answer is required")
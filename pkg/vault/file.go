@@ -0,0 +1,261 @@
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+const (
+	saltSize = 32
+	keySize  = 32
+)
+
+// fileStore is a credential store encrypted with passphrase
+type fileStore struct {
+	path       string
+	passphrase string
+}
+
+// encryptedFile is the format of vault file
+type encryptedFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// PassphraseFunc returns passphrase of the vault file, create is true if the vault file is created with the passphrase
+var PassphraseFunc = askPassphrase
+
+// passphrase is asked once per process, and shared by all file stores
+var (
+	passphraseMutex  sync.Mutex
+	cachedPassphrase string
+)
+
+// newFileStore creates encrypted file store
+func newFileStore(path string) *fileStore {
+	return &fileStore{
+		path: path,
+	}
+}
+
+// Get returns the item of key
+func (f *fileStore) Get(key string) (*Item, error) {
+	items, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	item, ok := items[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &item, nil
+}
+
+// Set stores the item with key
+func (f *fileStore) Set(key string, item Item) error {
+	return f.update(func(items map[string]Item) error {
+		items[key] = item
+		return nil
+	})
+}
+
+// Remove deletes the item of key
+func (f *fileStore) Remove(key string) error {
+	return f.update(func(items map[string]Item) error {
+		if _, ok := items[key]; !ok {
+			return errors.New("item does not exist in the vault")
+		}
+		delete(items, key)
+		return nil
+	})
+}
+
+// update modifies items while holding the lock of vault file, so that changes of other processes are not lost
+func (f *fileStore) update(modify func(map[string]Item) error) error {
+	// passphrase is asked before locking not to block other processes during the prompt
+	if _, err := f.getPassphrase(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return err
+	}
+
+	unlock, err := acquireLock(f.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	items, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	if err := modify(items); err != nil {
+		return err
+	}
+
+	return f.save(items)
+}
+
+// List returns keys of all items
+func (f *fileStore) List() ([]string, error) {
+	items, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// Clear removes vault file while holding the lock, so that it is not restored by update of other processes
+func (f *fileStore) Clear() error {
+	if !tools.FileExists(f.path) {
+		return nil
+	}
+
+	unlock, err := acquireLock(f.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// load decrypts vault file
+func (f *fileStore) load() (map[string]Item, error) {
+	items := map[string]Item{}
+	if !tools.FileExists(f.path) {
+		return items, nil
+	}
+
+	b, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var ef encryptedFile
+	if err := json.Unmarshal(b, &ef); err != nil {
+		return nil, err
+	}
+
+	gcm, err := f.cipher(ef.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := gcm.Open(nil, ef.Nonce, ef.Data, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt the vault, please check the passphrase")
+	}
+
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// save encrypts items and writes vault file
+func (f *fileStore) save(items map[string]Item) error {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return err
+	}
+
+	gcm, err := f.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(encryptedFile{
+		Salt:  salt,
+		Nonce: nonce,
+		Data:  gcm.Seal(nil, nonce, data, nil),
+	})
+	if err != nil {
+		return err
+	}
+
+	return tools.WriteFileAtomic(f.path, b, 0600)
+}
+
+// cipher creates AES-GCM cipher with the key derived from passphrase
+func (f *fileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	passphrase, err := f.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, 32768, 8, 1, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// getPassphrase returns passphrase of the store, which is asked only once per process
+func (f *fileStore) getPassphrase() (string, error) {
+	if len(f.passphrase) > 0 {
+		return f.passphrase, nil
+	}
+
+	passphraseMutex.Lock()
+	defer passphraseMutex.Unlock()
+
+	if len(cachedPassphrase) == 0 {
+		passphrase, err := PassphraseFunc(!tools.FileExists(f.path))
+		if err != nil {
+			return constants.EmptyString, err
+		}
+		cachedPassphrase = passphrase
+	}
+	f.passphrase = cachedPassphrase
+
+	return f.passphrase, nil
+}
@@ -0,0 +1,147 @@
+package vault
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "act-vault")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	PassphraseFunc = func(bool) (string, error) { return "passphrase", nil }
+	defer resetPassphrase()
+
+	path := filepath.Join(dir, "vault")
+	item := Item{
+		AccessKeyID:     "ASIAEXAMPLE",
+		SecretAccessKey: "secret",
+		SessionToken:    "token",
+		Expiration:      time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+	}
+
+	if err := newFileStore(path).Set("default/prod", item); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := newFileStore(path).Get("default/prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stored == nil || *stored != item {
+		t.Errorf("expected: %v, output: %v", item, stored)
+	}
+
+	if stored.IsExpired() {
+		t.Errorf("item should not be expired: %v", stored.Expiration)
+	}
+
+	resetPassphrase()
+	PassphraseFunc = func(bool) (string, error) { return "wrong", nil }
+	if _, err := newFileStore(path).Get("default/prod"); err == nil {
+		t.Errorf("vault should not be decrypted with wrong passphrase")
+	}
+}
+
+func TestFileStoreConcurrentSet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "act-vault")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	asked := 0
+	PassphraseFunc = func(bool) (string, error) {
+		asked++
+		return "passphrase", nil
+	}
+	defer resetPassphrase()
+
+	path := filepath.Join(dir, "vault")
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := newFileStore(path).Set(fmt.Sprintf("default/env-%d", i), Item{AccessKeyID: "ASIAEXAMPLE"}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	keys, err := newFileStore(path).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 5 {
+		t.Errorf("expected 5 items, output: %v", keys)
+	}
+
+	if asked != 1 {
+		t.Errorf("passphrase should be asked once, output: %d", asked)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "vault*"))
+	if len(matches) != 1 {
+		t.Errorf("temporary or lock files are left: %v", matches)
+	}
+}
+
+// resetPassphrase clears passphrase cached in the process
+func resetPassphrase() {
+	PassphraseFunc = askPassphrase
+	cachedPassphrase = ""
+}
+
+func TestFileStoreCreateAndClear(t *testing.T) {
+	dir, err := ioutil.TempDir("", "act-vault")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var created []bool
+	PassphraseFunc = func(create bool) (string, error) {
+		created = append(created, create)
+		return "passphrase", nil
+	}
+	defer resetPassphrase()
+
+	path := filepath.Join(dir, "vault")
+	if err := newFileStore(path).Set("default/prod", Item{AccessKeyID: "ASIAEXAMPLE"}); err != nil {
+		t.Fatal(err)
+	}
+
+	resetPassphrase()
+	PassphraseFunc = func(create bool) (string, error) {
+		created = append(created, create)
+		return "passphrase", nil
+	}
+	if _, err := newFileStore(path).Get("default/prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(created) != 2 || !created[0] || created[1] {
+		t.Errorf("passphrase should be confirmed only when the vault is created: %v", created)
+	}
+
+	if err := newFileStore(path).Clear(); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "vault*"))
+	if len(matches) != 0 {
+		t.Errorf("vault or lock files are left: %v", matches)
+	}
+}
@@ -0,0 +1,136 @@
+//go:build darwin
+// +build darwin
+
+package vault
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+
+	"github.com/keybase/go-keychain"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/tools"
+)
+
+// keychainStore is a credential store using macOS keychain
+type keychainStore struct {
+	keychain keychain.Keychain
+}
+
+// newKeychainStore opens act keychain, or creates new one if it does not exist
+func newKeychainStore() (Store, error) {
+	path := filepath.Join(constants.HomeDir(), "Library", "Keychains", constants.DefaultKeyChainPath)
+	if tools.FileExists(path) || tools.FileExists(path+"-db") {
+		return &keychainStore{keychain: keychain.NewWithPath(path)}, nil
+	}
+
+	kc, err := keychain.NewKeychainWithPrompt(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keychainStore{keychain: kc}, nil
+}
+
+// Get returns the item of key
+func (k *keychainStore) Get(key string) (*Item, error) {
+	query := k.newItem(key)
+	query.SetMatchLimit(keychain.MatchLimitOne)
+	query.SetReturnData(true)
+
+	results, err := keychain.QueryItem(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	var item Item
+	if err := json.Unmarshal(results[0].Data, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// Set stores the item with key
+func (k *keychainStore) Set(key string, item Item) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	ki := k.newItem(key)
+	ki.SetLabel(key)
+	ki.SetData(data)
+	ki.SetSynchronizable(keychain.SynchronizableNo)
+	ki.SetAccessible(keychain.AccessibleWhenUnlocked)
+	ki.UseKeychain(k.keychain)
+
+	err = keychain.AddItem(ki)
+	if err == keychain.ErrorDuplicateItem {
+		update := keychain.NewItem()
+		update.SetData(data)
+		return keychain.UpdateItem(k.newItem(key), update)
+	}
+
+	return err
+}
+
+// Remove deletes the item of key
+func (k *keychainStore) Remove(key string) error {
+	return keychain.DeleteItem(k.newItem(key))
+}
+
+// List returns keys of all items
+func (k *keychainStore) List() ([]string, error) {
+	query := k.newItem(constants.EmptyString)
+	query.SetMatchLimit(keychain.MatchLimitAll)
+	query.SetReturnAttributes(true)
+
+	results, err := keychain.QueryItem(query)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, r := range results {
+		keys = append(keys, r.Account)
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// Clear removes all items in act keychain
+func (k *keychainStore) Clear() error {
+	keys, err := k.List()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := k.Remove(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newItem creates keychain item for query
+func (k *keychainStore) newItem(key string) keychain.Item {
+	item := keychain.NewItem()
+	item.SetSecClass(keychain.SecClassGenericPassword)
+	item.SetService(constants.DefaultKeyChainAccount)
+	if len(key) > 0 {
+		item.SetAccount(key)
+	}
+	item.SetMatchSearchList(k.keychain)
+
+	return item
+}
@@ -0,0 +1,11 @@
+//go:build !darwin
+// +build !darwin
+
+package vault
+
+import "errors"
+
+// newKeychainStore returns error because keychain is only supported on macOS
+func newKeychainStore() (Store, error) {
+	return nil, errors.New("keychain vault is only supported on macOS, please use file type instead")
+}
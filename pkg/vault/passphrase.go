@@ -0,0 +1,77 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+)
+
+// errNoTerminal is returned when passphrase cannot be asked, e.g. act is run by credential_process of AWS SDK
+var errNoTerminal = fmt.Errorf("cannot ask passphrase of the vault without terminal, please set %s", constants.VaultPassphraseEnv)
+
+// askPassphrase gets passphrase from environment variable or terminal
+// Passphrase is asked twice when the vault file is created, so that typo does not lock out the vault.
+func askPassphrase(create bool) (string, error) {
+	if p := os.Getenv(constants.VaultPassphraseEnv); len(p) > 0 {
+		return p, nil
+	}
+
+	in, out, closeTerminal, err := openTerminal()
+	if err != nil {
+		return constants.EmptyString, err
+	}
+	defer closeTerminal()
+
+	passphrase, err := readPassphrase(in, out, "Vault passphrase: ")
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	if len(passphrase) == 0 {
+		return constants.EmptyString, errors.New("passphrase of the vault should not be empty")
+	}
+
+	if create {
+		confirm, err := readPassphrase(in, out, "Confirm vault passphrase: ")
+		if err != nil {
+			return constants.EmptyString, err
+		}
+
+		if confirm != passphrase {
+			return constants.EmptyString, errors.New("passphrases do not match")
+		}
+	}
+
+	return passphrase, nil
+}
+
+// openTerminal opens controlling terminal, or uses stdin and stderr if they are terminal
+// stdin and stderr of credential_process are not terminal, so /dev/tty is used like MFA token.
+func openTerminal() (*os.File, io.Writer, func(), error) {
+	if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+		return tty, tty, func() { tty.Close() }, nil
+	}
+
+	if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return os.Stdin, os.Stderr, func() {}, nil
+	}
+
+	return nil, nil, nil, errNoTerminal
+}
+
+// readPassphrase reads passphrase from terminal without echo
+func readPassphrase(in *os.File, out io.Writer, prompt string) (string, error) {
+	fmt.Fprint(out, prompt)
+	b, err := terminal.ReadPassword(int(in.Fd()))
+	fmt.Fprintln(out)
+	if err != nil {
+		return constants.EmptyString, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+
+	return string(b), nil
+}
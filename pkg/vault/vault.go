@@ -0,0 +1,123 @@
+package vault
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/DevopsArtFactory/act/pkg/constants"
+	"github.com/DevopsArtFactory/act/pkg/schema"
+)
+
+// Item is a credential stored in the vault
+type Item struct {
+	AccessKeyID     string    `json:"access_key_id"`
+	SecretAccessKey string    `json:"secret_access_key"`
+	SessionToken    string    `json:"session_token"`
+	Expiration      time.Time `json:"expiration"`
+}
+
+// Store is a storage of cached credentials
+type Store interface {
+	// Get returns the item of key, or nil if it does not exist
+	Get(key string) (*Item, error)
+	Set(key string, item Item) error
+	Remove(key string) error
+	List() ([]string, error)
+	Clear() error
+}
+
+// New creates credential store with the type of backend
+func New(keyType, path string) (Store, error) {
+	if len(keyType) == 0 {
+		keyType = DefaultType()
+	}
+
+	switch keyType {
+	case constants.DefaultKeyType:
+		return newKeychainStore()
+	case constants.FileKeyType:
+		if len(path) == 0 {
+			path = constants.DefaultVaultFilePath
+		}
+		return newFileStore(path), nil
+	}
+
+	return nil, fmt.Errorf("vault type is not supported: %s", keyType)
+}
+
+// DefaultType returns default type of backend for current platform
+func DefaultType() string {
+	if runtime.GOOS == "darwin" {
+		return constants.DefaultKeyType
+	}
+
+	return constants.FileKeyType
+}
+
+// AssumeRoleKey returns key of assume role credentials
+func AssumeRoleKey(profile string, chain []schema.RoleHop) string {
+	arns := []string{}
+	for _, hop := range chain {
+		arns = append(arns, hop.RoleArn)
+	}
+
+	return fmt.Sprintf("%s/%s", profile, strings.Join(arns, ","))
+}
+
+// MFASessionKey returns key of MFA session credentials
+func MFASessionKey(profile string) string {
+	return fmt.Sprintf("%s/mfa-session", profile)
+}
+
+// NewItem creates vault item from STS credentials
+func NewItem(creds *sts.Credentials) Item {
+	return Item{
+		AccessKeyID:     aws.StringValue(creds.AccessKeyId),
+		SecretAccessKey: aws.StringValue(creds.SecretAccessKey),
+		SessionToken:    aws.StringValue(creds.SessionToken),
+		Expiration:      aws.TimeValue(creds.Expiration),
+	}
+}
+
+// Credentials converts item to STS credentials
+func (i Item) Credentials() *sts.Credentials {
+	return &sts.Credentials{
+		AccessKeyId:     aws.String(i.AccessKeyID),
+		SecretAccessKey: aws.String(i.SecretAccessKey),
+		SessionToken:    aws.String(i.SessionToken),
+		Expiration:      aws.Time(i.Expiration),
+	}
+}
+
+// IsExpired checks if item is expired considering expiration window
+func (i Item) IsExpired() bool {
+	return time.Now().Add(constants.DefaultExpirationWindow).After(i.Expiration)
+}
+
+// GetOrCreate returns valid credentials in the store, otherwise create new one and store it
+func GetOrCreate(store Store, key string, create func() (*sts.Credentials, error)) (*sts.Credentials, error) {
+	item, err := store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if item != nil && !item.IsExpired() {
+		return item.Credentials(), nil
+	}
+
+	creds, err := create()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Set(key, NewItem(creds)); err != nil {
+		return nil, err
+	}
+
+	return creds, nil
+}